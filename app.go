@@ -11,6 +11,7 @@ import (
 
 	"github.com/charmbracelet/x/term"
 	"github.com/muesli/cancelreader"
+	"github.com/muesli/termenv"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -19,6 +20,9 @@ var (
 	ErrKilled      = errors.New("killed")
 )
 
+// defaultMaxPasteSize is the default maximum size of [PasteMsg], see [App.WithMaxPasteSize].
+const defaultMaxPasteSize = 1 << 20
+
 type Msg any
 
 type Cmd func() Msg
@@ -54,7 +58,7 @@ func (_InputDefault) getInput() (io.Reader, func() error, error) {
 	// input. This will allow things to "just work" in cases where data was
 	// piped in or redirected to the application.
 	//
-	// To disable input entirely pass nil to the [WithInput] program option.
+	// To disable input entirely pass nil to the [App.WithInput] option.
 	f, isFile := input.(term.File)
 	if !isFile {
 		return input, nil, nil
@@ -95,8 +99,19 @@ type App struct {
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 
-	input  _Input
-	output io.Writer
+	input     _Input
+	output    io.Writer
+	viewport  Viewport
+	mouseMode mouseMode
+
+	reportFocus         bool
+	keyboardEnhancement KeyboardEnhancement
+	noAltScreen         bool
+	noBracketedPaste    bool
+	noCtrlZSuspend      bool
+	maxPasteSize        int
+
+	colorProfile *termenv.Profile
 }
 
 func NewApp(model Model) App {
@@ -108,6 +123,9 @@ func NewApp(model Model) App {
 		cancelCtx: cancelCtx,
 		input:     _InputDefault{},
 		output:    os.Stdout,
+		viewport:  ViewportFullscreen{},
+
+		maxPasteSize: defaultMaxPasteSize,
 	}
 }
 
@@ -117,14 +135,144 @@ func (a App) WithContext(ctx context.Context) App {
 	return a
 }
 
+// WithInput sets the input the app reads key and mouse events from.
+// Defaults to stdin, or to a newly opened TTY if stdin is not a terminal.
+//
+// Pass nil to disable input entirely.
+func (a App) WithInput(input io.Reader) App {
+	a.input = _InputCustom{input}
+
+	return a
+}
+
+// WithInputTTY makes the app open a new TTY for input instead of using stdin.
+func (a App) WithInputTTY() App {
+	a.input = _InputTTY{}
+
+	return a
+}
+
+// WithOutput sets the output the app is rendered to.
+// Defaults to stdout.
+func (a App) WithOutput(output io.Writer) App {
+	a.output = output
+
+	return a
+}
+
+// WithColorProfile sets the color profile of the output terminal.
+// By default it is detected from the output and the process environment.
+func (a App) WithColorProfile(profile termenv.Profile) App {
+	a.colorProfile = &profile
+
+	return a
+}
+
+// WithoutAltScreen disables the alternate screen buffer,
+// so that the app is rendered into the main screen and the last frame is kept on exit.
+func (a App) WithoutAltScreen() App {
+	a.noAltScreen = true
+
+	return a
+}
+
+// WithoutBracketedPaste disables bracketed paste.
+// The pasted text is then received as regular key presses.
+func (a App) WithoutBracketedPaste() App {
+	a.noBracketedPaste = true
+
+	return a
+}
+
+// WithoutCtrlZSuspend disables suspending the app with ctrl+z, see [Suspend].
+// The key is then received as a regular key press, e.g. to undo in the input widgets.
+func (a App) WithoutCtrlZSuspend() App {
+	a.noCtrlZSuspend = true
+
+	return a
+}
+
+// WithMaxPasteSize sets the maximum size of [PasteMsg] in bytes, the rest of the pasted text is dropped.
+// Zero or negative size disables the limit. Defaults to 1 MiB.
+func (a App) WithMaxPasteSize(size int) App {
+	a.maxPasteSize = size
+
+	return a
+}
+
+// WithViewport sets the viewport the app is rendered into.
+// Defaults to [ViewportFullscreen].
+//
+// The alternate screen is used for all viewports except [ViewportInline],
+// which renders below the cursor and keeps the last frame in the scrollback on exit.
+func (a App) WithViewport(viewport Viewport) App {
+	a.viewport = viewport
+
+	return a
+}
+
+// WithMouseCellMotion enables mouse click, release and wheel events once the app starts.
+// Mouse movement events are also reported while a button is pressed (i.e. drag).
+//
+// See also [EnableMouseCellMotion] to enable it at runtime.
+func (a App) WithMouseCellMotion() App {
+	a.mouseMode = mouseModeCellMotion
+
+	return a
+}
+
+// WithMouseAllMotion enables mouse click, release, wheel and movement events once the app starts,
+// regardless of whether a button is pressed.
+//
+// See also [EnableMouseAllMotion] to enable it at runtime.
+func (a App) WithMouseAllMotion() App {
+	a.mouseMode = mouseModeAllMotion
+
+	return a
+}
+
+// WithReportFocus enables reporting of terminal focus changes once the app starts.
+// The app will receive [FocusMsg] and [BlurMsg] when the terminal gains and loses focus.
+//
+// See also [EnableReportFocus] to enable it at runtime.
+func (a App) WithReportFocus() App {
+	a.reportFocus = true
+
+	return a
+}
+
+// WithKeyboardEnhancement enables the kitty keyboard protocol with the given flags once the app starts.
+// Supporting terminals then report the modifiers in [Key.Mod], e.g. telling ctrl+i from tab,
+// and, depending on the flags, repeat events and [KeyReleaseMsg].
+// Other terminals keep sending the legacy key sequences.
+//
+// See https://sw.kovidgoyal.net/kitty/keyboard-protocol/
+func (a App) WithKeyboardEnhancement(flags KeyboardEnhancement) App {
+	a.keyboardEnhancement = flags
+
+	return a
+}
+
+// Run runs the app and blocks until it exits.
+// It returns the final model.
+//
+// See [App.Start] to run the app in the background and interact with it from other goroutines.
 func (a App) Run() (Model, error) {
+	return a.Start().Wait()
+}
+
+func (a App) run(msgs chan Msg) (Model, error) {
 	input, closeInput, err := a.input.getInput()
 	if err != nil {
 		return nil, fmt.Errorf("get input: %w", err)
 	}
 
 	backend := NewDefaultBackend(input, a.output)
-	terminal, err := NewTerminal(&backend, ViewportFullscreen{})
+	if a.colorProfile != nil {
+		backend = backend.WithColorProfile(*a.colorProfile)
+	}
+
+	terminal, err := NewTerminal(&backend, a.viewport)
 	if err != nil {
 		return nil, fmt.Errorf("new terminal: %w", err)
 	}
@@ -134,13 +282,21 @@ func (a App) Run() (Model, error) {
 		ctx:       a.ctx,
 		cancelCtx: a.cancelCtx,
 
-		readLoopDone: make(chan struct{}),
-		handlers:     channelHandlers{},
-		cmds:         make(chan Cmd),
-		msgs:         make(chan Msg),
-		errs:         make(chan error),
-		finished:     make(chan struct{}, 1),
-		terminal:     terminal,
+		readLoopDone:   make(chan struct{}),
+		handlers:       channelHandlers{},
+		cmds:           make(chan Cmd),
+		msgs:           msgs,
+		errs:           make(chan error),
+		finished:       make(chan struct{}, 1),
+		terminal:       terminal,
+		altScreen:      !a.noAltScreen && !isInline(a.viewport),
+		bracketedPaste: !a.noBracketedPaste,
+		maxPasteSize:   a.maxPasteSize,
+		ctrlZSuspend:   !a.noCtrlZSuspend,
+		mouseMode:      a.mouseMode,
+		reportFocus:    a.reportFocus,
+
+		keyboardEnhancement: a.keyboardEnhancement,
 
 		closeInput: closeInput,
 	}
@@ -154,7 +310,16 @@ type appRunner struct {
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 
-	terminal *Terminal
+	terminal       *Terminal
+	altScreen      bool
+	bracketedPaste bool
+	maxPasteSize   int
+	ctrlZSuspend   bool
+
+	// mouse mode, focus reporting and keyboard enhancement to enable on init
+	mouseMode           mouseMode
+	reportFocus         bool
+	keyboardEnhancement KeyboardEnhancement
 
 	cancelReader cancelreader.CancelReader
 	readLoopDone chan struct{}
@@ -222,6 +387,10 @@ func (a *appRunner) Run() (model Model, err error) {
 	}
 
 	if err != nil {
+		// Restore the terminal even if the app was killed,
+		// but report the original error.
+		_ = a.shutdown()
+
 		return model, err
 	}
 
@@ -263,6 +432,15 @@ func (a *appRunner) handleCommands() {
 	a.handlers.add(ch)
 }
 
+// sendCmd passes the command to the commands handler.
+// It doesn't block once the app is killed and the handler has stopped.
+func (a *appRunner) sendCmd(cmd Cmd) {
+	select {
+	case <-a.ctx.Done():
+	case a.cmds <- cmd:
+	}
+}
+
 func (a *appRunner) handleCmd(cmd Cmd) {
 	if cmd == nil {
 		return
@@ -282,17 +460,29 @@ func (a *appRunner) handleCmd(cmd Cmd) {
 }
 
 func (a *appRunner) handleResize() {
-	ch := make(chan struct{})
-
 	// Get the initial terminal size and send it to the program.
 	go a.checkResize()
 
-	// Listen for window resizes.
+	// Listen for window resizes only if the output is the local terminal.
+	// Otherwise, e.g. for SSH sessions, the size changes must be sent as WindowSizeMsg.
+	if !a.outputIsTerminal() {
+		return
+	}
+
+	ch := make(chan struct{})
+
 	go a.listenForResize(ch)
 
 	a.handlers.add(ch)
 }
 
+// outputIsTerminal reports whether the output is the local terminal.
+func (a *appRunner) outputIsTerminal() bool {
+	f, ok := a.terminal.Output().(term.File)
+
+	return ok && term.IsTerminal(f.Fd())
+}
+
 func (a *appRunner) eventLoop(model Model) (Model, error) {
 	for {
 		select {
@@ -307,14 +497,36 @@ func (a *appRunner) eventLoop(model Model) (Model, error) {
 				continue
 			}
 
+			if err := a.terminal.HandleMsg(msg); err != nil {
+				return model, err
+			}
+
+			if key, ok := msg.(KeyMsg); ok && a.ctrlZSuspend && a.canSuspend() && key.String() == "ctrl+z" {
+				msg = SuspendMsg{}
+			}
+
 			switch msg := msg.(type) {
 			case QuitMsg:
 				return model, nil
-			case WindowSizeMsg:
-				if err := a.terminal.Resize(NewRect(msg.Width, msg.Height)); err != nil {
-					return model, fmt.Errorf("resize: %w", err)
+			case SuspendMsg:
+				if err := a.suspend(); err != nil {
+					return model, fmt.Errorf("suspend: %w", err)
+				}
+
+				continue
+			case ExecMsg:
+				if err := a.exec(msg.Process); err != nil {
+					return model, fmt.Errorf("exec: %w", err)
 				}
-			case sequenceMsg:
+
+				continue
+			case BatchMsg:
+				for _, cmd := range msg {
+					a.sendCmd(cmd)
+				}
+
+				continue
+			case SequenceMsg:
 				go func() {
 					// Execute commands one at a time, in order.
 					for _, cmd := range msg {
@@ -344,7 +556,7 @@ func (a *appRunner) eventLoop(model Model) (Model, error) {
 
 			var cmd Cmd
 			model, cmd = model.Update(msg) // run update
-			a.cmds <- cmd
+			a.sendCmd(cmd)
 			a.draw(model)
 		}
 	}
@@ -363,6 +575,14 @@ func (a *appRunner) shutdown() error {
 
 	a.handlers.shutdown()
 
+	if a.cancelReader != nil {
+		if a.cancelReader.Cancel() {
+			a.waitForReadLoop()
+		}
+
+		_ = a.cancelReader.Close()
+	}
+
 	return a.restore()
 }
 
@@ -378,8 +598,39 @@ func (a *appRunner) init() error {
 		return fmt.Errorf("init terminal: %w", err)
 	}
 
-	if err := a.terminal.EnableAlternateScreen(); err != nil {
-		return fmt.Errorf("enable alt screen buffer: %w", err)
+	if a.altScreen {
+		if err := a.terminal.EnableAlternateScreen(); err != nil {
+			return fmt.Errorf("enable alt screen buffer: %w", err)
+		}
+	}
+
+	if a.bracketedPaste {
+		if err := a.terminal.EnableBracketedPaste(); err != nil {
+			return fmt.Errorf("enable bracketed paste: %w", err)
+		}
+	}
+
+	switch a.mouseMode {
+	case mouseModeCellMotion:
+		if err := a.terminal.EnableMouseCellMotion(); err != nil {
+			return fmt.Errorf("enable mouse cell motion: %w", err)
+		}
+	case mouseModeAllMotion:
+		if err := a.terminal.EnableMouseAllMotion(); err != nil {
+			return fmt.Errorf("enable mouse all motion: %w", err)
+		}
+	}
+
+	if a.reportFocus {
+		if err := a.terminal.EnableReportFocus(); err != nil {
+			return fmt.Errorf("enable report focus: %w", err)
+		}
+	}
+
+	if a.keyboardEnhancement != 0 {
+		if err := a.terminal.PushKeyboardEnhancement(a.keyboardEnhancement); err != nil {
+			return fmt.Errorf("push keyboard enhancement: %w", err)
+		}
 	}
 
 	return nil
@@ -390,8 +641,14 @@ func (a *appRunner) restore() error {
 		return fmt.Errorf("restore terminal: %w", err)
 	}
 
-	if err := a.terminal.LeaveAlternateScreen(); err != nil {
-		return fmt.Errorf("leave alt screen buffer: %w", err)
+	if a.altScreen {
+		if err := a.terminal.LeaveAlternateScreen(); err != nil {
+			return fmt.Errorf("leave alt screen buffer: %w", err)
+		}
+	} else if isInline(a.terminal.Viewport()) {
+		if err := a.terminal.InsertNewLineAfterViewport(); err != nil {
+			return fmt.Errorf("insert new line after viewport: %w", err)
+		}
 	}
 
 	return nil
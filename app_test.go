@@ -0,0 +1,27 @@
+package bento_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_WithoutAltScreen(t *testing.T) {
+	var output bytes.Buffer
+
+	program := bento.NewApp(recordModel{}).
+		WithInput(nil).
+		WithOutput(&output).
+		WithoutAltScreen().
+		WithoutBracketedPaste().
+		Start()
+
+	program.Quit()
+
+	_, err := program.Wait()
+	require.NoError(t, err)
+	require.NotContains(t, output.String(), "\x1b[?1049h")
+	require.NotContains(t, output.String(), "\x1b[?2004h")
+}
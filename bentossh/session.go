@@ -0,0 +1,153 @@
+// Package bentossh serves bento apps over SSH, running a separate app for each session.
+//
+// It does not depend on any SSH server library. Instead, the PTY session
+// of the library in use is described with a [Session]. For example, with
+// github.com/charmbracelet/ssh:
+//
+//	func handler(s ssh.Session) {
+//		pty, windowChanges, ok := s.Pty()
+//		if !ok {
+//			return
+//		}
+//
+//		windows := make(chan bentossh.Window)
+//
+//		go func() {
+//			defer close(windows)
+//
+//			for w := range windowChanges {
+//				windows <- bentossh.Window{Width: w.Width, Height: w.Height}
+//			}
+//		}()
+//
+//		session := bentossh.Session{
+//			Context:       s.Context(),
+//			ReadWriter:    s,
+//			Term:          pty.Term,
+//			Environ:       s.Environ(),
+//			Window:        bentossh.Window{Width: pty.Window.Width, Height: pty.Window.Height},
+//			WindowChanges: windows,
+//		}
+//
+//		_, _ = bentossh.Run(session, bentossh.NewApp(session, newModel()))
+//	}
+package bentossh
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/metafates/bento"
+	"github.com/muesli/termenv"
+)
+
+// Window is the size of the remote terminal window.
+type Window struct {
+	Width, Height int
+}
+
+// Session is an interactive SSH session with a PTY.
+type Session struct {
+	// Context is canceled when the connection is closed.
+	// If nil, the app is only stopped by itself.
+	Context context.Context
+
+	// ReadWriter is the session channel.
+	// Key presses are read from it and the app is rendered to it.
+	ReadWriter io.ReadWriter
+
+	// Term is the terminal type requested with the PTY, e.g. "xterm-256color".
+	Term string
+
+	// Environ is the environment sent by the client, in the "key=value" form.
+	Environ []string
+
+	// Window is the initial window size.
+	Window Window
+
+	// WindowChanges receives the window size changes.
+	// It may be nil if the size never changes.
+	WindowChanges <-chan Window
+}
+
+// ColorProfile returns the color profile of the client terminal.
+// It is detected from the terminal type and the client environment,
+// e.g. COLORTERM or NO_COLOR, instead of the server ones.
+func (s Session) ColorProfile() termenv.Profile {
+	env := environ(append(slices.Clip(s.Environ), "TERM="+s.Term))
+
+	return termenv.NewOutput(s.ReadWriter, termenv.WithEnvironment(env), termenv.WithUnsafe()).EnvColorProfile()
+}
+
+func (s Session) context() context.Context {
+	if s.Context == nil {
+		return context.Background()
+	}
+
+	return s.Context
+}
+
+// NewApp returns a new app running the model in the session.
+//
+// The app reads from and renders to the session channel using the session color profile,
+// and is stopped when the session context is canceled.
+// It can be further configured before passing to [Run].
+func NewApp(session Session, model bento.Model) bento.App {
+	return bento.NewApp(model).
+		WithContext(session.context()).
+		WithInput(session.ReadWriter).
+		WithOutput(session.ReadWriter).
+		WithColorProfile(session.ColorProfile())
+}
+
+// Run runs the app for the session and blocks until it exits.
+//
+// The initial window size and its changes are sent to the app as [bento.WindowSizeMsg].
+func Run(session Session, app bento.App) (bento.Model, error) {
+	program := app.Start()
+
+	go func() {
+		program.Send(windowSizeMsg(session.Window))
+
+		for {
+			select {
+			case <-program.Done():
+				return
+			case window, ok := <-session.WindowChanges:
+				if !ok {
+					return
+				}
+
+				program.Send(windowSizeMsg(window))
+			}
+		}
+	}()
+
+	return program.Wait()
+}
+
+func windowSizeMsg(window Window) bento.WindowSizeMsg {
+	return bento.WindowSizeMsg{Width: window.Width, Height: window.Height}
+}
+
+// environ implements [termenv.Environ] for the client environment.
+type environ []string
+
+func (e environ) Environ() []string {
+	return e
+}
+
+func (e environ) Getenv(key string) string {
+	var value string
+
+	// later values take precedence, like in os/exec
+	for _, kv := range e {
+		if k, v, ok := strings.Cut(kv, "="); ok && k == key {
+			value = v
+		}
+	}
+
+	return value
+}
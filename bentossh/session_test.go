@@ -0,0 +1,130 @@
+package bentossh_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentossh"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+)
+
+type sizesModel struct {
+	sizes []bento.WindowSizeMsg
+}
+
+func (m sizesModel) Init() bento.Cmd {
+	return nil
+}
+
+func (m sizesModel) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
+	switch msg := msg.(type) {
+	case bento.WindowSizeMsg:
+		m.sizes = append(m.sizes, msg)
+
+		if len(m.sizes) == 2 {
+			return m, bento.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (sizesModel) Render(bento.Rect, *bento.Buffer) {}
+
+type readWriter struct {
+	io.Reader
+	io.Writer
+}
+
+func TestRun(t *testing.T) {
+	input, inputWriter := io.Pipe()
+	defer inputWriter.Close()
+
+	var output bytes.Buffer
+
+	windowChanges := make(chan bentossh.Window, 1)
+	windowChanges <- bentossh.Window{Width: 100, Height: 40}
+
+	session := bentossh.Session{
+		Context:       context.Background(),
+		ReadWriter:    readWriter{Reader: input, Writer: &output},
+		Term:          "xterm-256color",
+		Window:        bentossh.Window{Width: 80, Height: 24},
+		WindowChanges: windowChanges,
+	}
+
+	model, err := bentossh.Run(session, bentossh.NewApp(session, sizesModel{}))
+	require.NoError(t, err)
+	require.Equal(t, []bento.WindowSizeMsg{
+		{Width: 80, Height: 24},
+		{Width: 100, Height: 40},
+	}, model.(sizesModel).sizes)
+	require.Contains(t, output.String(), "\x1b[?1049h")
+}
+
+func TestRun_NilContext(t *testing.T) {
+	input, inputWriter := io.Pipe()
+	defer inputWriter.Close()
+
+	windowChanges := make(chan bentossh.Window, 1)
+	windowChanges <- bentossh.Window{Width: 100, Height: 40}
+
+	session := bentossh.Session{
+		ReadWriter:    readWriter{Reader: input, Writer: io.Discard},
+		Term:          "xterm",
+		Window:        bentossh.Window{Width: 80, Height: 24},
+		WindowChanges: windowChanges,
+	}
+
+	model, err := bentossh.Run(session, bentossh.NewApp(session, sizesModel{}))
+	require.NoError(t, err)
+	require.Len(t, model.(sizesModel).sizes, 2)
+}
+
+func TestRun_SessionClosed(t *testing.T) {
+	input, inputWriter := io.Pipe()
+	defer inputWriter.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	session := bentossh.Session{
+		Context:    ctx,
+		ReadWriter: readWriter{Reader: input, Writer: io.Discard},
+		Term:       "xterm",
+	}
+
+	cancel()
+
+	_, err := bentossh.Run(session, bentossh.NewApp(session, sizesModel{}))
+	require.ErrorIs(t, err, bento.ErrKilled)
+}
+
+func TestSession_ColorProfile(t *testing.T) {
+	testCases := []struct {
+		name    string
+		term    string
+		environ []string
+		want    termenv.Profile
+	}{
+		{name: "256 colors", term: "xterm-256color", want: termenv.ANSI256},
+		{name: "true color", term: "xterm-256color", environ: []string{"COLORTERM=truecolor"}, want: termenv.TrueColor},
+		{name: "no color", term: "xterm-256color", environ: []string{"NO_COLOR=1"}, want: termenv.Ascii},
+		{name: "dumb", term: "dumb", want: termenv.Ascii},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			session := bentossh.Session{
+				ReadWriter: readWriter{Reader: bytes.NewReader(nil), Writer: io.Discard},
+				Term:       tc.term,
+				Environ:    tc.environ,
+			}
+
+			require.Equal(t, tc.want, session.ColorProfile())
+		})
+	}
+}
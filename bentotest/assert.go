@@ -0,0 +1,267 @@
+package bentotest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/textwidget"
+	"github.com/muesli/termenv"
+)
+
+// AssertBuffer fails the test if the buffers differ in area, symbols or styles.
+// The failure message shows both buffers and the list of differing cells.
+func AssertBuffer(t testing.TB, expected, actual bento.Buffer) {
+	t.Helper()
+
+	if expected.Area() != actual.Area() {
+		t.Fatalf(
+			"buffer areas differ\nexpected: %+v\nactual:   %+v\n\nexpected %s\nactual %s",
+			expected.Area(), actual.Area(),
+			FormatBuffer(expected), FormatBuffer(actual),
+		)
+	}
+
+	var diffs []string
+
+	area := actual.Area()
+
+	for y := area.Top(); y < area.Bottom(); y++ {
+		for x := area.Left(); x < area.Right(); x++ {
+			position := bento.Position{X: x, Y: y}
+
+			want := *expected.CellAt(position)
+			got := *actual.CellAt(position)
+
+			if cellsEqual(want, got) {
+				continue
+			}
+
+			diffs = append(diffs, fmt.Sprintf(
+				"  (%d, %d): expected %s, actual %s",
+				x, y, formatCell(want), formatCell(got),
+			))
+		}
+	}
+
+	if len(diffs) == 0 {
+		return
+	}
+
+	t.Fatalf(
+		"buffers differ\n\nexpected %s\nactual %s\ndiff:\n%s",
+		FormatBuffer(expected), FormatBuffer(actual), strings.Join(diffs, "\n"),
+	)
+}
+
+// AssertBufferLines fails the test if the buffer symbols differ from the given lines.
+// Styles are not compared.
+func AssertBufferLines(t testing.TB, lines []string, actual bento.Buffer) {
+	t.Helper()
+
+	expected := textwidget.NewLinesStr(lines...).NewBuffer()
+
+	if expected.Area().Width != actual.Area().Width || expected.Area().Height != actual.Area().Height {
+		t.Fatalf(
+			"buffer sizes differ\nexpected: %dx%d\nactual:   %dx%d\n\nexpected %s\nactual %s",
+			expected.Area().Width, expected.Area().Height,
+			actual.Area().Width, actual.Area().Height,
+			formatLines(expected), formatLines(actual),
+		)
+	}
+
+	if !slices.Equal(bufferLines(expected), bufferLines(actual)) {
+		t.Fatalf("buffer lines differ\n\nexpected %s\nactual %s", formatLines(expected), formatLines(actual))
+	}
+}
+
+// AssertBuffer fails the test if the screen content differs from the expected buffer.
+func (b *Backend) AssertBuffer(t testing.TB, expected bento.Buffer) {
+	t.Helper()
+
+	AssertBuffer(t, expected, b.buffer)
+}
+
+// AssertBufferLines fails the test if the screen symbols differ from the given lines.
+func (b *Backend) AssertBufferLines(t testing.TB, lines ...string) {
+	t.Helper()
+
+	AssertBufferLines(t, lines, b.buffer)
+}
+
+// AssertScrollbackLines fails the test if the scrollback symbols differ from the given lines.
+func (b *Backend) AssertScrollbackLines(t testing.TB, lines ...string) {
+	t.Helper()
+
+	scrollback := b.Scrollback()
+
+	if len(lines) == 0 && scrollback.Area().Height == 0 {
+		return
+	}
+
+	AssertBufferLines(t, lines, scrollback)
+}
+
+// AssertCursorPosition fails the test if the cursor is not at the given position.
+func (b *Backend) AssertCursorPosition(t testing.TB, position bento.Position) {
+	t.Helper()
+
+	if b.cursorPosition != position {
+		t.Fatalf("cursor positions differ\nexpected: %+v\nactual:   %+v", position, b.cursorPosition)
+	}
+}
+
+// FormatBuffer returns a readable representation of the buffer,
+// with the symbols on one line per row followed by the styles of each styled cell.
+func FormatBuffer(buffer bento.Buffer) string {
+	var b strings.Builder
+
+	b.WriteString(formatLines(buffer))
+
+	area := buffer.Area()
+
+	var styles []string
+
+	for y := area.Top(); y < area.Bottom(); y++ {
+		for x := area.Left(); x < area.Right(); x++ {
+			cell := buffer.CellAt(bento.Position{X: x, Y: y})
+
+			if isDefaultStyle(*cell) {
+				continue
+			}
+
+			styles = append(styles, fmt.Sprintf("  (%d, %d): %s", x, y, formatStyle(*cell)))
+		}
+	}
+
+	if len(styles) > 0 {
+		b.WriteString("styles:\n")
+		b.WriteString(strings.Join(styles, "\n"))
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+func formatLines(buffer bento.Buffer) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "buffer %+v:\n", buffer.Area())
+
+	for _, line := range bufferLines(buffer) {
+		b.WriteString("  ")
+		b.WriteString(strconv.Quote(line))
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// bufferLines returns the symbols of each row, skipping the cells hidden by wide symbols.
+func bufferLines(buffer bento.Buffer) []string {
+	area := buffer.Area()
+
+	lines := make([]string, 0, area.Height)
+
+	for y := area.Top(); y < area.Bottom(); y++ {
+		var (
+			line   strings.Builder
+			toSkip int
+		)
+
+		for x := area.Left(); x < area.Right(); x++ {
+			if toSkip > 0 {
+				toSkip--
+				continue
+			}
+
+			cell := buffer.CellAt(bento.Position{X: x, Y: y})
+
+			line.WriteString(cell.Symbol)
+
+			toSkip = max(0, textwidget.NewSpan(cell.Symbol).Width()-1)
+		}
+
+		lines = append(lines, line.String())
+	}
+
+	return lines
+}
+
+func cellsEqual(a, b bento.Cell) bool {
+	return a.Symbol == b.Symbol &&
+		a.Skip == b.Skip &&
+		a.Modifier == b.Modifier &&
+		formatColor(a.Fg) == formatColor(b.Fg) &&
+		formatColor(a.Bg) == formatColor(b.Bg)
+}
+
+func isDefaultStyle(cell bento.Cell) bool {
+	return cell.Modifier == bento.ModifierNone &&
+		formatColor(cell.Fg) == formatColor(bento.ResetColor{}) &&
+		formatColor(cell.Bg) == formatColor(bento.ResetColor{})
+}
+
+func formatCell(cell bento.Cell) string {
+	return fmt.Sprintf("%s %s", strconv.Quote(cell.Symbol), formatStyle(cell))
+}
+
+func formatStyle(cell bento.Cell) string {
+	return fmt.Sprintf(
+		"fg: %s, bg: %s, modifier: %s",
+		formatColor(cell.Fg), formatColor(cell.Bg), formatModifier(cell.Modifier),
+	)
+}
+
+func formatColor(color bento.Color) string {
+	switch c := color.(type) {
+	case nil:
+		return "Nil"
+	case bento.ResetColor:
+		return "Reset"
+	case termenv.NoColor:
+		return "None"
+	case termenv.ANSIColor:
+		return fmt.Sprintf("ANSI(%d)", c)
+	case termenv.ANSI256Color:
+		return fmt.Sprintf("ANSI256(%d)", c)
+	case termenv.RGBColor:
+		return fmt.Sprintf("RGB(%s)", string(c))
+	default:
+		return fmt.Sprintf("%T(%v)", c, c)
+	}
+}
+
+var modifierNames = []struct {
+	modifier bento.Modifier
+	name     string
+}{
+	{bento.ModifierBold, "Bold"},
+	{bento.ModifierDim, "Dim"},
+	{bento.ModifierItalic, "Italic"},
+	{bento.ModifierUnderlined, "Underlined"},
+	{bento.ModifierSlowBlink, "SlowBlink"},
+	{bento.ModifierRapidBlink, "RapidBlink"},
+	{bento.ModifierReversed, "Reversed"},
+	{bento.ModifierHidden, "Hidden"},
+	{bento.ModifierCrossedOut, "CrossedOut"},
+}
+
+func formatModifier(modifier bento.Modifier) string {
+	if modifier == bento.ModifierNone {
+		return "None"
+	}
+
+	var names []string
+
+	for _, m := range modifierNames {
+		if modifier.Contains(m.modifier) {
+			names = append(names, m.name)
+		}
+	}
+
+	return strings.Join(names, " | ")
+}
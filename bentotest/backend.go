@@ -0,0 +1,403 @@
+package bentotest
+
+import (
+	"io"
+
+	"github.com/metafates/bento"
+)
+
+var _ bento.TerminalBackend = (*Backend)(nil)
+
+// Backend is an in-memory [bento.TerminalBackend] for tests.
+//
+// It keeps the grid of cells drawn so far, the cursor state and the terminal modes
+// instead of writing escape sequences, so that whole models can be tested
+// through [bento.Terminal] without a TTY.
+type Backend struct {
+	buffer     bento.Buffer
+	scrollback [][]bento.Cell
+	flushes    []bento.Buffer
+
+	cursorPosition bento.Position
+	cursorShape    bento.CursorShape
+	cursorVisible  bool
+
+	rawMode        bool
+	altScreen      bool
+	bracketedPaste bool
+	mouseMode      MouseMode
+	reportFocus    bool
+
+	keyboardEnhancements []bento.KeyboardEnhancement
+}
+
+// MouseMode is the kind of mouse events reporting enabled in the [Backend].
+type MouseMode int
+
+const (
+	MouseModeNone MouseMode = iota
+	MouseModeCellMotion
+	MouseModeAllMotion
+)
+
+// NewBackend returns a new backend with the screen of the given size.
+func NewBackend(width, height int) *Backend {
+	return &Backend{
+		buffer:        bento.NewBufferEmpty(bento.NewRect(width, height)),
+		cursorVisible: true,
+	}
+}
+
+// Buffer returns the cells currently drawn on the screen.
+func (b *Backend) Buffer() bento.Buffer {
+	return b.buffer.Clone()
+}
+
+// Scrollback returns the lines scrolled off the top of the screen, oldest first.
+//
+// The lines are clipped or padded to the current screen width,
+// since they keep the width they were scrolled off at.
+func (b *Backend) Scrollback() bento.Buffer {
+	area := b.buffer.Area()
+
+	buffer := bento.NewBufferEmpty(bento.NewRect(area.Width, len(b.scrollback)))
+
+	for y, row := range b.scrollback {
+		for x, cell := range row[:min(len(row), area.Width)] {
+			*buffer.CellAt(bento.Position{X: x, Y: y}) = cell
+		}
+	}
+
+	return buffer
+}
+
+// Flushes returns the screen contents captured at each [Backend.Flush] call, oldest first.
+func (b *Backend) Flushes() []bento.Buffer {
+	return b.flushes
+}
+
+// Resize resizes the screen. The content is cleared.
+func (b *Backend) Resize(width, height int) {
+	b.buffer = bento.NewBufferEmpty(bento.NewRect(width, height))
+
+	b.cursorPosition = bento.Position{
+		X: max(0, min(b.cursorPosition.X, width-1)),
+		Y: max(0, min(b.cursorPosition.Y, height-1)),
+	}
+}
+
+func (b *Backend) CursorVisible() bool {
+	return b.cursorVisible
+}
+
+func (b *Backend) CursorShape() bento.CursorShape {
+	return b.cursorShape
+}
+
+func (b *Backend) RawMode() bool {
+	return b.rawMode
+}
+
+func (b *Backend) AlternateScreen() bool {
+	return b.altScreen
+}
+
+func (b *Backend) BracketedPaste() bool {
+	return b.bracketedPaste
+}
+
+func (b *Backend) MouseMode() MouseMode {
+	return b.mouseMode
+}
+
+func (b *Backend) ReportFocus() bool {
+	return b.reportFocus
+}
+
+// KeyboardEnhancement returns the kitty keyboard protocol flags pushed last, or zero if none are.
+func (b *Backend) KeyboardEnhancement() bento.KeyboardEnhancement {
+	if len(b.keyboardEnhancements) == 0 {
+		return 0
+	}
+
+	return b.keyboardEnhancements[len(b.keyboardEnhancements)-1]
+}
+
+// Read implements bento.TerminalBackend.
+// There is no input, so it always returns [io.EOF].
+func (b *Backend) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
+
+// Input implements bento.TerminalBackend.
+func (b *Backend) Input() io.Reader {
+	return b
+}
+
+// Output implements bento.TerminalBackend.
+func (b *Backend) Output() io.Writer {
+	return io.Discard
+}
+
+// Draw implements bento.TerminalBackend.
+func (b *Backend) Draw(cells []bento.PositionedCell) error {
+	area := b.buffer.Area()
+
+	for _, c := range cells {
+		if !area.Contains(c.Position) {
+			continue
+		}
+
+		*b.buffer.CellAt(c.Position) = c.Cell
+
+		b.cursorPosition = c.Position
+	}
+
+	return nil
+}
+
+// AppendLines implements bento.TerminalBackend.
+//
+// Like a new line in raw mode, the cursor moves down keeping its column
+// and the screen scrolls once the cursor is at the bottom.
+func (b *Backend) AppendLines(n int) error {
+	area := b.buffer.Area()
+
+	for i := 0; i < n; i++ {
+		if b.cursorPosition.Y < area.Height-1 {
+			b.cursorPosition.Y++
+			continue
+		}
+
+		b.scrollUp()
+	}
+
+	return nil
+}
+
+func (b *Backend) scrollUp() {
+	area := b.buffer.Area()
+
+	if area.IsEmpty() {
+		return
+	}
+
+	top := make([]bento.Cell, 0, area.Width)
+
+	for x := 0; x < area.Width; x++ {
+		top = append(top, *b.buffer.CellAt(bento.Position{X: x, Y: 0}))
+	}
+
+	b.scrollback = append(b.scrollback, top)
+
+	for y := 1; y < area.Height; y++ {
+		for x := 0; x < area.Width; x++ {
+			*b.buffer.CellAt(bento.Position{X: x, Y: y - 1}) = *b.buffer.CellAt(bento.Position{X: x, Y: y})
+		}
+	}
+
+	b.clearRange(bento.Position{X: 0, Y: area.Height - 1}, bento.Position{X: area.Width - 1, Y: area.Height - 1})
+}
+
+// Flush implements bento.TerminalBackend.
+func (b *Backend) Flush() error {
+	b.flushes = append(b.flushes, b.buffer.Clone())
+
+	return nil
+}
+
+// GetCursorPosition implements bento.TerminalBackend.
+func (b *Backend) GetCursorPosition() (bento.Position, error) {
+	return b.cursorPosition, nil
+}
+
+// SetCursorPosition implements bento.TerminalBackend.
+func (b *Backend) SetCursorPosition(position bento.Position) error {
+	b.cursorPosition = position
+
+	return nil
+}
+
+// SetCursorShape implements bento.TerminalBackend.
+func (b *Backend) SetCursorShape(shape bento.CursorShape) error {
+	b.cursorShape = shape
+
+	return nil
+}
+
+// GetSize implements bento.TerminalBackend.
+func (b *Backend) GetSize() (bento.Size, bool, error) {
+	area := b.buffer.Area()
+
+	return bento.Size{Width: area.Width, Height: area.Height}, true, nil
+}
+
+// HideCursor implements bento.TerminalBackend.
+func (b *Backend) HideCursor() error {
+	b.cursorVisible = false
+
+	return nil
+}
+
+// ShowCursor implements bento.TerminalBackend.
+func (b *Backend) ShowCursor() error {
+	b.cursorVisible = true
+
+	return nil
+}
+
+// ClearAll implements bento.TerminalBackend.
+func (b *Backend) ClearAll() error {
+	b.buffer.Reset()
+
+	return nil
+}
+
+// ClearAfterCursor implements bento.TerminalBackend.
+func (b *Backend) ClearAfterCursor() error {
+	area := b.buffer.Area()
+
+	b.clearRange(b.cursorPosition, bento.Position{X: area.Width - 1, Y: area.Height - 1})
+
+	return nil
+}
+
+// ClearBeforeCursor implements bento.TerminalBackend.
+func (b *Backend) ClearBeforeCursor() error {
+	b.clearRange(bento.Position{}, b.cursorPosition)
+
+	return nil
+}
+
+// ClearCurrentLine implements bento.TerminalBackend.
+func (b *Backend) ClearCurrentLine() error {
+	area := b.buffer.Area()
+	y := b.cursorPosition.Y
+
+	b.clearRange(bento.Position{X: 0, Y: y}, bento.Position{X: area.Width - 1, Y: y})
+
+	return nil
+}
+
+// ClearUntilNewLine implements bento.TerminalBackend.
+func (b *Backend) ClearUntilNewLine() error {
+	area := b.buffer.Area()
+
+	b.clearRange(b.cursorPosition, bento.Position{X: area.Width - 1, Y: b.cursorPosition.Y})
+
+	return nil
+}
+
+// clearRange resets the cells from start to end inclusive, in the reading order.
+func (b *Backend) clearRange(start, end bento.Position) {
+	area := b.buffer.Area()
+
+	for y := max(0, start.Y); y <= min(end.Y, area.Height-1); y++ {
+		from, to := 0, area.Width-1
+
+		if y == start.Y {
+			from = max(0, start.X)
+		}
+
+		if y == end.Y {
+			to = min(to, end.X)
+		}
+
+		for x := from; x <= to; x++ {
+			b.buffer.CellAt(bento.Position{X: x, Y: y}).Reset()
+		}
+	}
+}
+
+// EnableRawMode implements bento.TerminalBackend.
+func (b *Backend) EnableRawMode() error {
+	b.rawMode = true
+
+	return nil
+}
+
+// DisableRawMode implements bento.TerminalBackend.
+func (b *Backend) DisableRawMode() error {
+	b.rawMode = false
+
+	return nil
+}
+
+// EnableAlternateScreen implements bento.TerminalBackend.
+func (b *Backend) EnableAlternateScreen() error {
+	b.altScreen = true
+
+	return nil
+}
+
+// LeaveAlternateScreen implements bento.TerminalBackend.
+func (b *Backend) LeaveAlternateScreen() error {
+	b.altScreen = false
+
+	return nil
+}
+
+// EnableBracketedPaste implements bento.TerminalBackend.
+func (b *Backend) EnableBracketedPaste() error {
+	b.bracketedPaste = true
+
+	return nil
+}
+
+// DisableBracketedPaste implements bento.TerminalBackend.
+func (b *Backend) DisableBracketedPaste() error {
+	b.bracketedPaste = false
+
+	return nil
+}
+
+// EnableMouseCellMotion implements bento.TerminalBackend.
+func (b *Backend) EnableMouseCellMotion() error {
+	b.mouseMode = MouseModeCellMotion
+
+	return nil
+}
+
+// EnableMouseAllMotion implements bento.TerminalBackend.
+func (b *Backend) EnableMouseAllMotion() error {
+	b.mouseMode = MouseModeAllMotion
+
+	return nil
+}
+
+// DisableMouse implements bento.TerminalBackend.
+func (b *Backend) DisableMouse() error {
+	b.mouseMode = MouseModeNone
+
+	return nil
+}
+
+// EnableReportFocus implements bento.TerminalBackend.
+func (b *Backend) EnableReportFocus() error {
+	b.reportFocus = true
+
+	return nil
+}
+
+// DisableReportFocus implements bento.TerminalBackend.
+func (b *Backend) DisableReportFocus() error {
+	b.reportFocus = false
+
+	return nil
+}
+
+// PushKeyboardEnhancement implements bento.TerminalBackend.
+func (b *Backend) PushKeyboardEnhancement(flags bento.KeyboardEnhancement) error {
+	b.keyboardEnhancements = append(b.keyboardEnhancements, flags)
+
+	return nil
+}
+
+// PopKeyboardEnhancement implements bento.TerminalBackend.
+func (b *Backend) PopKeyboardEnhancement() error {
+	if len(b.keyboardEnhancements) > 0 {
+		b.keyboardEnhancements = b.keyboardEnhancements[:len(b.keyboardEnhancements)-1]
+	}
+
+	return nil
+}
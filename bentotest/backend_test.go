@@ -0,0 +1,160 @@
+package bentotest_test
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/metafates/bento/textwidget"
+)
+
+func TestBackend_Draw(t *testing.T) {
+	backend := bentotest.NewBackend(10, 2)
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportFullscreen{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := textwidget.NewText(
+		textwidget.NewLineStr("Hello").WithStyle(bento.NewStyle().Bold()),
+		textwidget.NewLineStr("World"),
+	)
+
+	if _, err := terminal.Draw(text); err != nil {
+		t.Fatal(err)
+	}
+
+	want := textwidget.NewLinesStr(
+		"Hello     ",
+		"World     ",
+	).NewBuffer()
+	want.SetStyle(bento.Rect{Width: 10, Height: 1}, bento.NewStyle().Bold())
+
+	backend.AssertBuffer(t, want)
+
+	if backend.CursorVisible() {
+		t.Fatal("cursor must be hidden")
+	}
+
+	if len(backend.Flushes()) != 1 {
+		t.Fatalf("expected 1 flush, got %d", len(backend.Flushes()))
+	}
+}
+
+func TestBackend_CursorPosition(t *testing.T) {
+	backend := bentotest.NewBackend(10, 2)
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportFullscreen{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	widget := widgetFunc(func(area bento.Rect, buffer *bento.Buffer) {
+		buffer.SetCursorPosition(bento.Position{X: 3, Y: 1})
+		buffer.SetCursorShape(bento.CursorShapeSteadyBar)
+	})
+
+	if _, err := terminal.Draw(widget); err != nil {
+		t.Fatal(err)
+	}
+
+	backend.AssertCursorPosition(t, bento.Position{X: 3, Y: 1})
+
+	if !backend.CursorVisible() {
+		t.Fatal("cursor must be visible")
+	}
+
+	if backend.CursorShape() != bento.CursorShapeSteadyBar {
+		t.Fatalf("unexpected cursor shape %d", backend.CursorShape())
+	}
+}
+
+func TestBackend_InsertBefore(t *testing.T) {
+	backend := bentotest.NewBackend(10, 4)
+
+	if err := backend.SetCursorPosition(bento.Position{X: 0, Y: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportInline(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viewport := textwidget.NewTextStr("viewport")
+
+	if _, err := terminal.Draw(viewport); err != nil {
+		t.Fatal(err)
+	}
+
+	backend.AssertBufferLines(t,
+		"          ",
+		"          ",
+		"viewport  ",
+		"          ",
+	)
+
+	for _, line := range []string{"first", "second", "third"} {
+		err := terminal.InsertBefore(1, func(buffer *bento.Buffer) {
+			textwidget.NewTextStr(line).Render(buffer.Area(), buffer)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := terminal.Draw(viewport); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backend.AssertScrollbackLines(t,
+		"          ",
+		"          ",
+		"first     ",
+	)
+	backend.AssertBufferLines(t,
+		"second    ",
+		"third     ",
+		"viewport  ",
+		"          ",
+	)
+}
+
+func TestBackend_ScrollbackAfterResize(t *testing.T) {
+	backend := bentotest.NewBackend(6, 2)
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportInline(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = terminal.InsertBefore(3, func(buffer *bento.Buffer) {
+		buffer.SetString(0, 0, "first", bento.NewStyle())
+		buffer.SetString(0, 1, "second", bento.NewStyle())
+		buffer.SetString(0, 2, "third", bento.NewStyle())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the lines scrolled off before are clipped
+	backend.Resize(3, 2)
+	backend.AssertScrollbackLines(t,
+		"fir",
+		"sec",
+	)
+
+	// and padded
+	backend.Resize(8, 2)
+	backend.AssertScrollbackLines(t,
+		"first   ",
+		"second  ",
+	)
+}
+
+type widgetFunc func(area bento.Rect, buffer *bento.Buffer)
+
+func (f widgetFunc) Render(area bento.Rect, buffer *bento.Buffer) {
+	f(area, buffer)
+}
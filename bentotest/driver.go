@@ -0,0 +1,275 @@
+package bentotest
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/metafates/bento"
+)
+
+// DefaultMaxSteps is the default value of [Driver.MaxSteps].
+const DefaultMaxSteps = 1000
+
+// Driver runs a [bento.Model] synchronously against a headless [Backend].
+//
+// Unlike the app, it has no goroutines, input reader or signal handlers.
+// Messages are delivered to the model one at a time and commands are executed
+// in the order they were returned, so that tests are deterministic.
+// The model is drawn after each update.
+type Driver struct {
+	// MaxSteps limits the number of commands executed after each message.
+	// The rest of the commands are kept pending and can be executed with [Driver.Step] or [Driver.Settle].
+	// It prevents endless command chains, e.g. ticks, from blocking the test forever.
+	MaxSteps int
+
+	// RunProcess runs the processes of the [bento.Exec] commands instead of the app.
+	// If nil, the processes are not run and succeed.
+	RunProcess func(process *exec.Cmd) error
+
+	t        testing.TB
+	backend  *Backend
+	terminal *bento.Terminal
+	model    bento.Model
+	pending  []bento.Cmd
+	quit     bool
+	suspends int
+
+	processes []*exec.Cmd
+}
+
+// NewDriver returns a new driver for the model with the fullscreen terminal of the given size.
+//
+// Like the app, it sends the initial [bento.WindowSizeMsg] and executes the commands returned by the model Init.
+func NewDriver(t testing.TB, model bento.Model, width, height int) *Driver {
+	t.Helper()
+
+	backend := NewBackend(width, height)
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportFullscreen{})
+	if err != nil {
+		t.Fatalf("new terminal: %v", err)
+	}
+
+	d := &Driver{
+		MaxSteps: DefaultMaxSteps,
+		t:        t,
+		backend:  backend,
+		terminal: terminal,
+		model:    model,
+	}
+
+	d.enqueue(model.Init())
+	d.draw()
+	d.Send(bento.WindowSizeMsg{Width: width, Height: height})
+
+	return d
+}
+
+// Model returns the current model.
+func (d *Driver) Model() bento.Model {
+	return d.model
+}
+
+// Backend returns the backend the model is drawn to.
+func (d *Driver) Backend() *Backend {
+	return d.backend
+}
+
+// Buffer returns the cells currently drawn on the screen.
+func (d *Driver) Buffer() bento.Buffer {
+	return d.backend.Buffer()
+}
+
+// Quit reports whether the model has quit, i.e. [bento.QuitMsg] was received.
+// Once quit, all further messages and commands are ignored.
+func (d *Driver) Quit() bool {
+	return d.quit
+}
+
+// Suspends returns the number of times the model was suspended with [bento.Suspend].
+// There is no process to stop, so the model receives [bento.ResumeMsg] right away.
+func (d *Driver) Suspends() int {
+	return d.suspends
+}
+
+// Processes returns the processes of the [bento.Exec] commands, oldest first.
+// The model receives [bento.ExecFinishedMsg] right after each of them, see [Driver.RunProcess].
+func (d *Driver) Processes() []*exec.Cmd {
+	return d.processes
+}
+
+// Pending returns the number of commands waiting to be executed.
+func (d *Driver) Pending() int {
+	return len(d.pending)
+}
+
+// Send delivers the messages to the model in order.
+// After each message, pending commands are executed until none are left or [Driver.MaxSteps] is reached.
+func (d *Driver) Send(msgs ...bento.Msg) {
+	d.t.Helper()
+
+	for _, msg := range msgs {
+		d.handle(msg)
+		d.run(d.MaxSteps)
+	}
+}
+
+// Type sends a key message for each rune of the string.
+func (d *Driver) Type(s string) {
+	d.t.Helper()
+
+	for _, r := range s {
+		key := bento.Key{Type: bento.KeyRunes, Runes: []rune{r}}
+
+		if r == ' ' {
+			key.Type = bento.KeySpace
+		}
+
+		d.Send(bento.KeyMsg(key))
+	}
+}
+
+// Press sends a key message for each key type, e.g. [bento.KeyEnter].
+func (d *Driver) Press(keys ...bento.KeyType) {
+	d.t.Helper()
+
+	for _, key := range keys {
+		d.Send(bento.KeyMsg{Type: key})
+	}
+}
+
+// Resize resizes the screen and sends the matching [bento.WindowSizeMsg].
+func (d *Driver) Resize(width, height int) {
+	d.t.Helper()
+
+	d.backend.Resize(width, height)
+	d.Send(bento.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Step executes the next pending command and delivers its message.
+// It returns false if there were no pending commands.
+func (d *Driver) Step() bool {
+	d.t.Helper()
+
+	if d.quit || len(d.pending) == 0 {
+		return false
+	}
+
+	cmd := d.pending[0]
+	d.pending = d.pending[1:]
+
+	d.handle(cmd())
+
+	return true
+}
+
+// Settle executes pending commands until none are left or [Driver.MaxSteps] is reached.
+func (d *Driver) Settle() {
+	d.t.Helper()
+
+	d.run(d.MaxSteps)
+}
+
+func (d *Driver) run(steps int) {
+	d.t.Helper()
+
+	for i := 0; i < steps; i++ {
+		if !d.Step() {
+			return
+		}
+	}
+}
+
+// handle mirrors the app event loop for a single message.
+func (d *Driver) handle(msg bento.Msg) {
+	d.t.Helper()
+
+	if msg == nil || d.quit {
+		return
+	}
+
+	if err := d.terminal.HandleMsg(msg); err != nil {
+		d.t.Fatalf("handle msg %T: %v", msg, err)
+	}
+
+	switch msg := msg.(type) {
+	case bento.QuitMsg:
+		d.quit = true
+		return
+	case bento.BatchMsg:
+		for _, cmd := range msg {
+			d.enqueue(cmd)
+		}
+
+		return
+	case bento.SuspendMsg:
+		d.suspends++
+		d.handle(bento.ResumeMsg{})
+
+		return
+	case bento.ExecMsg:
+		d.processes = append(d.processes, msg.Process)
+
+		var err error
+
+		if d.RunProcess != nil {
+			err = d.RunProcess(msg.Process)
+		}
+
+		d.handle(bento.ExecFinishedMsg{Process: msg.Process, Err: err})
+
+		return
+	case bento.SequenceMsg:
+		d.update(msg)
+
+		for _, cmd := range msg {
+			if cmd == nil {
+				continue
+			}
+
+			msg := cmd()
+
+			// Like the app, wait for the whole batch before moving on.
+			if batch, ok := msg.(bento.BatchMsg); ok {
+				for _, cmd := range batch {
+					if cmd != nil {
+						d.handle(cmd())
+					}
+				}
+
+				continue
+			}
+
+			d.handle(msg)
+		}
+
+		return
+	}
+
+	d.update(msg)
+}
+
+func (d *Driver) update(msg bento.Msg) {
+	d.t.Helper()
+
+	var cmd bento.Cmd
+
+	d.model, cmd = d.model.Update(msg)
+
+	d.enqueue(cmd)
+	d.draw()
+}
+
+func (d *Driver) enqueue(cmd bento.Cmd) {
+	if cmd != nil {
+		d.pending = append(d.pending, cmd)
+	}
+}
+
+func (d *Driver) draw() {
+	d.t.Helper()
+
+	if _, err := d.terminal.Draw(d.model); err != nil {
+		d.t.Fatalf("draw: %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package bentotest_test
+
+import (
+	"errors"
+	"os/exec"
+	"strconv"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/metafates/bento/textwidget"
+)
+
+type incrementMsg struct{}
+
+func increment() bento.Msg {
+	return incrementMsg{}
+}
+
+type counterModel struct {
+	count  int
+	width  int
+	height int
+	log    []string
+
+	execErr error
+}
+
+func (m counterModel) Init() bento.Cmd {
+	return bento.Batch(increment, increment)
+}
+
+func (m counterModel) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
+	switch msg := msg.(type) {
+	case bento.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case incrementMsg:
+		m.count++
+	case bento.ResumeMsg:
+		m.log = append(m.log, "resume")
+	case bento.ExecFinishedMsg:
+		m.log = append(m.log, "exec "+msg.Process.Path)
+		m.execErr = msg.Err
+	case bento.KeyMsg:
+		m.log = append(m.log, msg.String())
+
+		switch msg.String() {
+		case "+":
+			return m, increment
+		case "s":
+			return m, bento.Suspend
+		case "x":
+			return m, bento.Exec(&exec.Cmd{Path: "editor"})
+		case "enter":
+			return m, bento.Sequence(increment, bento.Batch(increment, increment), bento.Quit)
+		}
+	}
+
+	return m, nil
+}
+
+func (m counterModel) Render(area bento.Rect, buffer *bento.Buffer) {
+	textwidget.NewText(
+		textwidget.NewLineStr("count: "+strconv.Itoa(m.count)),
+		textwidget.NewLineStr(strconv.Itoa(m.width)+"x"+strconv.Itoa(m.height)),
+	).Render(area, buffer)
+}
+
+func TestDriver(t *testing.T) {
+	driver := bentotest.NewDriver(t, counterModel{}, 12, 2)
+
+	driver.Backend().AssertBufferLines(t,
+		"count: 2    ",
+		"12x2        ",
+	)
+
+	driver.Type("+ +")
+
+	model := driver.Model().(counterModel)
+
+	if model.count != 4 {
+		t.Fatalf("expected count 4, got %d", model.count)
+	}
+
+	if got := len(model.log); got != 3 {
+		t.Fatalf("expected 3 keys, got %d", got)
+	}
+
+	if model.log[1] != " " {
+		t.Fatalf("expected space key, got %q", model.log[1])
+	}
+
+	driver.Resize(10, 3)
+	driver.Backend().AssertBufferLines(t,
+		"count: 4  ",
+		"10x3      ",
+		"          ",
+	)
+
+	driver.Press(bento.KeyEnter)
+
+	if !driver.Quit() {
+		t.Fatal("expected driver to quit")
+	}
+
+	driver.Backend().AssertBufferLines(t,
+		"count: 7  ",
+		"10x3      ",
+		"          ",
+	)
+
+	driver.Type("+")
+
+	if got := driver.Model().(counterModel).count; got != 7 {
+		t.Fatalf("expected messages to be ignored after quit, got count %d", got)
+	}
+}
+
+func TestDriver_Suspend(t *testing.T) {
+	driver := bentotest.NewDriver(t, counterModel{}, 12, 2)
+
+	driver.Type("s")
+
+	if driver.Suspends() != 1 {
+		t.Fatalf("expected 1 suspend, got %d", driver.Suspends())
+	}
+
+	log := driver.Model().(counterModel).log
+
+	if len(log) != 2 || log[1] != "resume" {
+		t.Fatalf("expected the model to be resumed, got %q", log)
+	}
+}
+
+func TestDriver_Exec(t *testing.T) {
+	driver := bentotest.NewDriver(t, counterModel{}, 12, 2)
+
+	driver.Type("x")
+
+	if got := len(driver.Processes()); got != 1 {
+		t.Fatalf("expected 1 process, got %d", got)
+	}
+
+	model := driver.Model().(counterModel)
+
+	if len(model.log) != 2 || model.log[1] != "exec editor" || model.execErr != nil {
+		t.Fatalf("expected the process to succeed, got %q, %v", model.log, model.execErr)
+	}
+
+	errExit := errors.New("exit status 1")
+
+	driver.RunProcess = func(process *exec.Cmd) error {
+		return errExit
+	}
+
+	driver.Type("x")
+
+	if got := driver.Model().(counterModel).execErr; !errors.Is(got, errExit) {
+		t.Fatalf("expected the process error, got %v", got)
+	}
+}
+
+func TestDriver_MaxSteps(t *testing.T) {
+	driver := bentotest.NewDriver(t, counterModel{}, 12, 2)
+	driver.MaxSteps = 0
+
+	driver.Type("++")
+
+	if driver.Pending() != 2 {
+		t.Fatalf("expected 2 pending commands, got %d", driver.Pending())
+	}
+
+	if !driver.Step() {
+		t.Fatal("expected step to execute a command")
+	}
+
+	if driver.Pending() != 1 {
+		t.Fatalf("expected 1 pending command, got %d", driver.Pending())
+	}
+
+	driver.MaxSteps = bentotest.DefaultMaxSteps
+	driver.Settle()
+
+	if driver.Pending() != 0 {
+		t.Fatal("expected no pending commands")
+	}
+
+	if got := driver.Model().(counterModel).count; got != 4 {
+		t.Fatalf("expected count 4, got %d", got)
+	}
+}
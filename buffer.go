@@ -12,6 +12,10 @@ type Buffer struct {
 
 	// content of the buffer. The length of this Vec should always be equal to [Area.Width] * [Area.Height]
 	content []Cell
+
+	// cursorPosition requested during the render. The cursor is hidden if nil
+	cursorPosition *Position
+	cursorShape    CursorShape
 }
 
 func NewBufferEmpty(area Rect) Buffer {
@@ -32,6 +36,19 @@ func NewBufferFilled(area Rect, cell Cell) Buffer {
 	}
 }
 
+// Clone returns a deep copy of the buffer.
+func (b *Buffer) Clone() Buffer {
+	clone := *b
+	clone.content = slices.Clone(b.content)
+
+	if b.cursorPosition != nil {
+		position := *b.cursorPosition
+		clone.cursorPosition = &position
+	}
+
+	return clone
+}
+
 // Area of the buffer
 func (b *Buffer) Area() Rect {
 	return b.area
@@ -98,6 +115,38 @@ func (b *Buffer) Reset() {
 	for i := range b.content {
 		b.content[i].Reset()
 	}
+
+	b.cursorPosition = nil
+	b.cursorShape = CursorShapeDefault
+}
+
+// SetCursorPosition requests the terminal cursor to be shown at the given position
+// once the buffer is drawn, e.g. for text inputs. This lets input method editors and
+// screen readers know where the text is being edited.
+//
+// The last requested position wins. The cursor is hidden if no position was requested.
+func (b *Buffer) SetCursorPosition(position Position) {
+	b.cursorPosition = &position
+}
+
+// SetCursorShape sets the shape of the cursor requested with [Buffer.SetCursorPosition].
+func (b *Buffer) SetCursorShape(shape CursorShape) {
+	b.cursorShape = shape
+}
+
+// CursorPosition returns the requested cursor position.
+// Returns ok = false if the cursor should be hidden.
+func (b *Buffer) CursorPosition() (position Position, ok bool) {
+	if b.cursorPosition == nil {
+		return Position{}, false
+	}
+
+	return *b.cursorPosition, true
+}
+
+// CursorShape returns the requested cursor shape.
+func (b *Buffer) CursorShape() CursorShape {
+	return b.cursorShape
 }
 
 // SetStringN prints at most the first n characters of a string if enough space is available
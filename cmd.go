@@ -1,23 +1,64 @@
 package bento
 
-import "time"
+import (
+	"os/exec"
+	"time"
+)
 
 type (
 	QuitMsg       struct{}
 	BatchMsg      []Cmd
+	SequenceMsg   []Cmd
 	WindowSizeMsg Size
 
 	// FocusMsg represents a terminal focus message.
 	// This occurs when the terminal gains focus.
+	//
+	// Focus reporting must be enabled with [App.WithReportFocus] or [EnableReportFocus].
 	FocusMsg struct{}
 
 	// BlurMsg represents a terminal blur message.
 	// This occurs when the terminal loses focus.
+	//
+	// Focus reporting must be enabled with [App.WithReportFocus] or [EnableReportFocus].
 	BlurMsg struct{}
+
+	// SuspendMsg is returned by the [Suspend] command.
+	// It is handled by the app and is never received by the model.
+	SuspendMsg struct{}
+
+	// ResumeMsg is sent when the app is resumed after being suspended with [Suspend]
+	// or ctrl+z, see [App.WithoutCtrlZSuspend].
+	ResumeMsg struct{}
+
+	// ExecMsg is returned by the [Exec] command.
+	// It is handled by the app and is never received by the model.
+	ExecMsg struct {
+		Process *exec.Cmd
+	}
+
+	// ExecFinishedMsg is sent when the process run with [Exec] exits.
+	ExecFinishedMsg struct {
+		Process *exec.Cmd
+
+		// Err is the error returned by [exec.Cmd.Run],
+		// e.g. [*exec.ExitError] if the process exited with a non-zero status.
+		Err error
+	}
 )
 
 type (
-	sequenceMsg []Cmd
+	enableMouseCellMotionMsg struct{}
+	enableMouseAllMotionMsg  struct{}
+	disableMouseMsg          struct{}
+
+	enableReportFocusMsg  struct{}
+	disableReportFocusMsg struct{}
+
+	insertBeforeMsg struct {
+		height int
+		draw   func(buffer *Buffer)
+	}
 )
 
 // Quit is a special command that tells the Bento app to exit.
@@ -25,11 +66,94 @@ func Quit() Msg {
 	return QuitMsg{}
 }
 
+// Suspend is a command that suspends the app, like ctrl+z does in a shell.
+// The terminal is restored and the process is stopped until it is continued, e.g. with fg.
+// Then the terminal is set up again and the app receives [ResumeMsg].
+//
+// It has no effect on Windows or when the output is not a terminal, e.g. in an SSH session.
+func Suspend() Msg {
+	return SuspendMsg{}
+}
+
+// Exec is a command that hands the terminal to the process, e.g. $EDITOR or a pager.
+// The app stops reading the input and restores the terminal while the process runs.
+// Then the terminal is set up again, the app is redrawn and receives [ExecFinishedMsg].
+//
+// The standard input, output and error of the process are the app ones unless set.
+//
+//	editor := exec.Command(os.Getenv("EDITOR"), path)
+//
+//	return m, bento.Exec(editor)
+func Exec(process *exec.Cmd) Cmd {
+	return func() Msg {
+		return ExecMsg{Process: process}
+	}
+}
+
+// EnableMouseCellMotion is a command that enables mouse click, release and wheel events.
+// Mouse movement events are also reported while a button is pressed (i.e. drag).
+//
+// To enable mouse cell motion once the app starts use [App.WithMouseCellMotion].
+func EnableMouseCellMotion() Msg {
+	return enableMouseCellMotionMsg{}
+}
+
+// EnableMouseAllMotion is a command that enables mouse click, release, wheel and movement events,
+// regardless of whether a button is pressed. Many modern terminals support this,
+// but not all. If in doubt, use [EnableMouseCellMotion] instead.
+//
+// To enable mouse all motion once the app starts use [App.WithMouseAllMotion].
+func EnableMouseAllMotion() Msg {
+	return enableMouseAllMotionMsg{}
+}
+
+// DisableMouse is a command that disables mouse events reporting.
+// Mouse is disabled automatically when the app exits.
+func DisableMouse() Msg {
+	return disableMouseMsg{}
+}
+
+// EnableReportFocus is a command that enables reporting of terminal focus changes.
+// The app will receive [FocusMsg] and [BlurMsg] when the terminal gains and loses focus.
+//
+// To enable focus reporting once the app starts use [App.WithReportFocus].
+func EnableReportFocus() Msg {
+	return enableReportFocusMsg{}
+}
+
+// DisableReportFocus is a command that disables reporting of terminal focus changes.
+// Focus reporting is disabled automatically when the app exits.
+func DisableReportFocus() Msg {
+	return disableReportFocusMsg{}
+}
+
+// InsertBefore is a command that prints content above the inline viewport.
+// The content is drawn into a buffer of the given height by the draw function
+// and is kept in the terminal scrollback, e.g. to log completed steps above a progress bar.
+//
+// Any widget can be printed this way:
+//
+//	lines := textwidget.NewLinesStr("Step 1 done")
+//
+//	return m, bento.InsertBefore(lines.Height(), func(buffer *bento.Buffer) {
+//		textwidget.NewText(lines...).Render(buffer.Area(), buffer)
+//	})
+//
+// It has no effect when the app is not using [ViewportInline].
+func InsertBefore(height int, draw func(buffer *Buffer)) Cmd {
+	return func() Msg {
+		return insertBeforeMsg{
+			height: height,
+			draw:   draw,
+		}
+	}
+}
+
 // Sequence runs the given commands one at a time, in order. Contrast this with
 // Batch, which runs commands concurrently.
 func Sequence(cmds ...Cmd) Cmd {
 	return func() Msg {
-		return sequenceMsg(cmds)
+		return SequenceMsg(cmds)
 	}
 }
 
@@ -0,0 +1,15 @@
+package bento
+
+// CursorShape is the shape of the terminal cursor.
+type CursorShape int
+
+const (
+	// CursorShapeDefault is the shape configured by the user in the terminal.
+	CursorShapeDefault CursorShape = iota
+	CursorShapeBlinkingBlock
+	CursorShapeSteadyBlock
+	CursorShapeBlinkingUnderline
+	CursorShapeSteadyUnderline
+	CursorShapeBlinkingBar
+	CursorShapeSteadyBar
+)
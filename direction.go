@@ -1,8 +1,21 @@
 package bento
 
+import "fmt"
+
 type Direction int
 
 const (
 	DirectionVertical Direction = iota
 	DirectionHorizontal
 )
+
+func (d Direction) String() string {
+	switch d {
+	case DirectionVertical:
+		return "Vertical"
+	case DirectionHorizontal:
+		return "Horizontal"
+	default:
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+}
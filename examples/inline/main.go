@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/gaugewidget"
+	"github.com/metafates/bento/textwidget"
+	"github.com/metafates/bento/throbberwidget"
+)
+
+var _ bento.Model = (*Model)(nil)
+
+type Model struct {
+	throbber throbberwidget.State
+	percent  int
+}
+
+// Init implements bento.Model.
+func (m *Model) Init() bento.Cmd {
+	return m.throbber.Tick
+}
+
+// Render implements bento.Model.
+func (m *Model) Render(area bento.Rect, buffer *bento.Buffer) {
+	var statusArea, gaugeArea bento.Rect
+
+	bento.
+		NewLayout(
+			bento.ConstraintLen(1),
+			bento.ConstraintLen(1),
+		).
+		Vertical().
+		Split(area).
+		Assign(&statusArea, &gaugeArea)
+
+	var throbberArea, labelArea bento.Rect
+
+	bento.
+		NewLayout(
+			bento.ConstraintLen(2),
+			bento.ConstraintFill(1),
+		).
+		Horizontal().
+		Split(statusArea).
+		Assign(&throbberArea, &labelArea)
+
+	throbberwidget.New().RenderStateful(throbberArea, buffer, m.throbber)
+	textwidget.NewTextStr("Building...").Render(labelArea, buffer)
+
+	gaugewidget.
+		New().
+		WithPercent(m.percent).
+		WithGaugeStyle(bento.NewStyle().Green()).
+		Render(gaugeArea, buffer)
+}
+
+// Update implements bento.Model.
+func (m *Model) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
+	consumed, cmd := m.throbber.TryUpdate(msg)
+	if consumed {
+		// pretend that some work is done on each frame
+		m.percent += 2
+
+		if m.percent%20 != 0 {
+			return m, cmd
+		}
+
+		step := logStep(m.percent / 20)
+
+		if m.percent >= 100 {
+			return m, bento.Sequence(step, bento.Quit)
+		}
+
+		return m, bento.Sequence(step, cmd)
+	}
+
+	switch msg := msg.(type) {
+	case bento.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, bento.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// logStep prints a line above the viewport that is kept in the scrollback.
+func logStep(step int) bento.Cmd {
+	line := textwidget.NewLine(
+		textwidget.NewSpan("✓ ").WithStyle(bento.NewStyle().Green()),
+		textwidget.NewSpan(fmt.Sprintf("Step %d done", step)),
+	)
+
+	return bento.InsertBefore(1, func(buffer *bento.Buffer) {
+		line.Render(buffer.Area(), buffer)
+	})
+}
+
+func run() error {
+	model := Model{
+		throbber: throbberwidget.NewState(),
+	}
+
+	model.throbber.FPS = 50 * time.Millisecond
+
+	_, err := bento.NewApp(&model).WithViewport(bento.ViewportInline(2)).Run()
+	if err != nil {
+		return fmt.Errorf("app run: %w", err)
+	}
+
+	return nil
+}
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalln(err)
+	}
+}
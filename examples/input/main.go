@@ -19,7 +19,10 @@ type Model struct {
 
 func (m *Model) Render(area bento.Rect, buffer *bento.Buffer) {
 	block := blockwidget.New().Bordered().Thick().WithTitleStr("Input")
-	input := inputwidget.New().WithPlaceholder("Placeholder...").WithPrompt("> ")
+	input := inputwidget.New().
+		WithPlaceholder("Placeholder...").
+		WithPrompt("> ").
+		WithTerminalCursor(bento.CursorShapeBlinkingBar)
 
 	popup := popupwidget.New().WithBlock(block).WithHeight(bento.ConstraintLen(3))
 
@@ -56,7 +59,8 @@ func (m *Model) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
 func run() error {
 	model := Model{input: inputwidget.NewState()}
 
-	_, err := bento.NewApp(&model).Run()
+	// ctrl+z undoes the input instead of suspending the app
+	_, err := bento.NewApp(&model).WithoutCtrlZSuspend().Run()
 	if err != nil {
 		return fmt.Errorf("app run: %w", err)
 	}
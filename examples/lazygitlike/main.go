@@ -3,6 +3,9 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/metafates/bento"
 	"github.com/metafates/bento/blockwidget"
@@ -59,6 +62,7 @@ func (p Panel) Title() string {
 type Model struct {
 	size        bento.Size
 	activePanel Panel
+	commandLog  []string
 }
 
 func (m *Model) Render(area bento.Rect, buffer *bento.Buffer) {
@@ -80,7 +84,7 @@ func (m *Model) Render(area bento.Rect, buffer *bento.Buffer) {
 
 func (m *Model) drawFootnote(area bento.Rect, buffer *bento.Buffer) {
 	left := textwidget.NewLine(
-		textwidget.NewSpan("Quit: q / ctrl+c").WithStyle(bento.NewStyle().Blue()),
+		textwidget.NewSpan("Quit: q / ctrl+c, Edit: e").WithStyle(bento.NewStyle().Blue()),
 	).Left()
 
 	right := textwidget.NewLine(
@@ -286,6 +290,8 @@ func (m *Model) renderCommandLog(area bento.Rect, buffer *bento.Buffer) {
 	block := m.newBlock(PanelCommandLog, "")
 
 	block.Render(area, buffer)
+
+	textwidget.NewTextStr(strings.Join(m.commandLog, "\n")).Render(block.Inner(area), buffer)
 }
 
 func (m *Model) newBlock(panel Panel, footer string) blockwidget.Block {
@@ -324,12 +330,31 @@ func (m *Model) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
 			m.activePanel = m.activePanel.Prev()
 		case "tab":
 			m.activePanel = m.activePanel.Next()
+		case "e":
+			return m, bento.Exec(editorCommand())
+		}
+	case bento.ExecFinishedMsg:
+		entry := "Edited with " + msg.Process.Path
+		if msg.Err != nil {
+			entry = fmt.Sprintf("%s: %s", msg.Process.Path, msg.Err)
 		}
+
+		m.commandLog = append(m.commandLog, entry)
 	}
 
 	return m, nil
 }
 
+// editorCommand returns the command opening $EDITOR, like lazygit does to edit files.
+func editorCommand() *exec.Cmd {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	return exec.Command(editor)
+}
+
 func run() error {
 	_, err := bento.NewApp(&Model{activePanel: PanelFiles}).Run()
 	if err != nil {
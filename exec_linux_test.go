@@ -0,0 +1,118 @@
+package bento_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// openPTY opens a pseudo terminal of the given size and returns its master and slave sides.
+func openPTY(t *testing.T, width, height int) (master, slave *os.File) {
+	t.Helper()
+
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("open pty: %v", err)
+	}
+
+	t.Cleanup(func() { master.Close() })
+
+	fd := int(master.Fd())
+
+	require.NoError(t, unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0))
+
+	n, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	require.NoError(t, err)
+
+	slave, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { slave.Close() })
+
+	err = unix.IoctlSetWinsize(int(slave.Fd()), unix.TIOCSWINSZ, &unix.Winsize{Row: uint16(height), Col: uint16(width)})
+	require.NoError(t, err)
+
+	return master, slave
+}
+
+// fakeTerminal reads the output written to the pty master
+// and replies to the cursor position queries with the given rows, one per query.
+type fakeTerminal struct {
+	mu     sync.Mutex
+	output bytes.Buffer
+}
+
+func (f *fakeTerminal) run(master *os.File, rows ...int) {
+	var buf [1024]byte
+
+	for {
+		n, err := master.Read(buf[:])
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.output.Write(buf[:n])
+		queries := strings.Count(string(buf[:n]), "\x1b[6n")
+		f.mu.Unlock()
+
+		for ; queries > 0 && len(rows) > 0; queries-- {
+			fmt.Fprintf(master, "\x1b[%d;1R", rows[0]+1)
+			rows = rows[1:]
+		}
+	}
+}
+
+func (f *fakeTerminal) String() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.output.String()
+}
+
+func TestExec_Inline(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	master, slave := openPTY(t, 10, 6)
+
+	var terminal fakeTerminal
+
+	// the viewport starts at the third row, and the cursor is two rows lower after the process
+	go terminal.run(master, 2, 4)
+
+	process := exec.Command("sh", "-c", "echo out; echo put")
+
+	model, err := bento.NewApp(execModel{process: process}).
+		WithInput(slave).
+		WithOutput(slave).
+		WithViewport(bento.ViewportInline(2)).
+		Run()
+	require.NoError(t, err)
+	require.NotNil(t, model.(execModel).finished)
+	require.NoError(t, model.(execModel).finished.Err)
+
+	output := terminal.String()
+
+	// the frame is cleared for the process instead of kept above its output
+	requireInOrder(t, output, "\x1b[3;1Happ", "\x1b[3;1H\x1b[J", "out\r\nput\r\n")
+
+	// after the last frame before the process
+	released := output[:strings.Index(output, "out")]
+	released = released[strings.LastIndex(released, "app"):]
+	require.NotContains(t, released, "\n")
+
+	// and the viewport is placed below the output afterwards
+	requireInOrder(t, output, "put\r\n", "\x1b[6n", "\x1b[5;1Happ")
+	require.NotContains(t, output[strings.Index(output, "put"):], "\x1b[3;1H")
+}
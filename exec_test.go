@@ -0,0 +1,65 @@
+package bento_test
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+type execModel struct {
+	process  *exec.Cmd
+	finished *bento.ExecFinishedMsg
+}
+
+func (m execModel) Init() bento.Cmd {
+	return bento.Exec(m.process)
+}
+
+func (m execModel) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
+	if msg, ok := msg.(bento.ExecFinishedMsg); ok {
+		m.finished = &msg
+
+		return m, bento.Quit
+	}
+
+	return m, nil
+}
+
+func (execModel) Render(area bento.Rect, buffer *bento.Buffer) {
+	buffer.SetString(area.X, area.Y, "app", bento.NewStyle())
+}
+
+func TestExec(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	var output bytes.Buffer
+
+	process := exec.Command("sh", "-c", "echo from process; exit 3")
+
+	model, err := bento.NewApp(execModel{process: process}).
+		WithInput(nil).
+		WithOutput(&output).
+		Run()
+	require.NoError(t, err)
+
+	finished := model.(execModel).finished
+	require.NotNil(t, finished)
+	require.Same(t, process, finished.Process)
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, finished.Err, &exitErr)
+	require.Equal(t, 3, exitErr.ExitCode())
+
+	// the process runs outside of the alt screen, which is entered again afterwards
+	out := output.String()
+	processAt := strings.Index(out, "from process")
+	require.Positive(t, processAt)
+	require.Contains(t, out[:processAt], "\x1b[?1049l")
+	require.Contains(t, out[processAt:], "\x1b[?1049h")
+}
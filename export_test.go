@@ -0,0 +1,3 @@
+package bento
+
+var ComputeInlineSize = computeInlineSize
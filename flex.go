@@ -1,5 +1,7 @@
 package bento
 
+import "fmt"
+
 type Flex int
 
 const (
@@ -10,3 +12,22 @@ const (
 	FlexSpaceBetween
 	FlexSpaceAround
 )
+
+func (f Flex) String() string {
+	switch f {
+	case FlexLegacy:
+		return "Legacy"
+	case FlexStart:
+		return "Start"
+	case FlexEnd:
+		return "End"
+	case FlexCenter:
+		return "Center"
+	case FlexSpaceBetween:
+		return "SpaceBetween"
+	case FlexSpaceAround:
+		return "SpaceAround"
+	default:
+		return fmt.Sprintf("Flex(%d)", int(f))
+	}
+}
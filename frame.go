@@ -7,10 +7,9 @@ type CompletedFrame struct {
 }
 
 type Frame struct {
-	cursorPosition *Position
-	viewportArea   Rect
-	buffer         *Buffer
-	count          int
+	viewportArea Rect
+	buffer       *Buffer
+	count        int
 }
 
 func (f *Frame) RenderWidget(widget Widget, area Rect) {
@@ -20,3 +19,17 @@ func (f *Frame) RenderWidget(widget Widget, area Rect) {
 func (f *Frame) Area() Rect {
 	return f.viewportArea
 }
+
+// SetCursorPosition requests the terminal cursor to be shown at the given position
+// after this frame is drawn. The cursor is hidden if no position is set during the frame.
+//
+// Widgets which only have access to the buffer can use [Buffer.SetCursorPosition] instead.
+func (f *Frame) SetCursorPosition(position Position) {
+	f.buffer.SetCursorPosition(position)
+}
+
+// SetCursorShape sets the shape of the terminal cursor shown after this frame is drawn.
+// It has effect only if the cursor position is set.
+func (f *Frame) SetCursorShape(shape CursorShape) {
+	f.buffer.SetCursorShape(shape)
+}
@@ -0,0 +1,284 @@
+package bento
+
+import (
+	"fmt"
+
+	"github.com/metafates/bento/internal/casso"
+)
+
+// Grid splits an area into rows and columns.
+//
+// Rows and columns are solved together, so that every column has the same width in all rows
+// and every row has the same height in all columns. The same [Flex] and [Spacing] semantics
+// as in [Layout] apply to each axis.
+//
+// Spans and relations take part in the same solve, e.g. to fit a title
+// in the cells it spans or to keep the cells square.
+type Grid struct {
+	Rows          []Constraint
+	Columns       []Constraint
+	Padding       Padding
+	Flex          Flex
+	RowSpacing    Spacing
+	ColumnSpacing Spacing
+
+	// Spans are constraints on the areas of cells spanning several rows or columns.
+	Spans []GridSpan
+
+	// Relations are additional relations between row and column sizes, see [RowSize] and [ColumnSize].
+	Relations []Relation
+}
+
+// GridSpan constrains the area of the cells spanning the given number of rows and columns,
+// see [GridCells.Span]. Spans are clamped to the grid bounds and are at least one cell.
+//
+// A nil constraint leaves that size unconstrained.
+type GridSpan struct {
+	Row, Column         int
+	RowSpan, ColumnSpan int
+
+	Width, Height Constraint
+}
+
+func NewGridSpan(row, column, rowSpan, columnSpan int) GridSpan {
+	return GridSpan{
+		Row:        row,
+		Column:     column,
+		RowSpan:    rowSpan,
+		ColumnSpan: columnSpan,
+	}
+}
+
+func (s GridSpan) WithWidth(width Constraint) GridSpan {
+	s.Width = width
+	return s
+}
+
+func (s GridSpan) WithHeight(height Constraint) GridSpan {
+	s.Height = height
+	return s
+}
+
+func NewGrid(rows, columns []Constraint) Grid {
+	return Grid{
+		Rows:          rows,
+		Columns:       columns,
+		Padding:       NewPadding(),
+		Flex:          FlexLegacy,
+		RowSpacing:    SpacingSpace(0),
+		ColumnSpacing: SpacingSpace(0),
+	}
+}
+
+func (g Grid) WithRows(rows ...Constraint) Grid {
+	g.Rows = rows
+	return g
+}
+
+func (g Grid) WithColumns(columns ...Constraint) Grid {
+	g.Columns = columns
+	return g
+}
+
+func (g Grid) WithPadding(padding Padding) Grid {
+	g.Padding = padding
+	return g
+}
+
+func (g Grid) WithFlex(flex Flex) Grid {
+	g.Flex = flex
+	return g
+}
+
+// WithSpacing sets the spacing between both rows and columns.
+func (g Grid) WithSpacing(spacing Spacing) Grid {
+	g.RowSpacing = spacing
+	g.ColumnSpacing = spacing
+	return g
+}
+
+func (g Grid) WithRowSpacing(spacing Spacing) Grid {
+	g.RowSpacing = spacing
+	return g
+}
+
+func (g Grid) WithColumnSpacing(spacing Spacing) Grid {
+	g.ColumnSpacing = spacing
+	return g
+}
+
+// WithSpans adds constraints on the spanned cells, see [GridSpan].
+func (g Grid) WithSpans(spans ...GridSpan) Grid {
+	g.Spans = append(g.Spans, spans...)
+	return g
+}
+
+// WithRelations adds relations between row and column sizes, e.g.
+//
+//	// the first cell is square
+//	bento.RowSize(0).Equal(bento.ColumnSize(0))
+func (g Grid) WithRelations(relations ...Relation) Grid {
+	g.Relations = append(g.Relations, relations...)
+	return g
+}
+
+// Split splits the area into cells.
+// It panics if the constraints can not be solved, see [Grid.TrySplit].
+func (g Grid) Split(area Rect) GridCells {
+	cells, err := g.TrySplit(area)
+	if err != nil {
+		panic(err)
+	}
+
+	return cells
+}
+
+// TrySplit is like [Grid.Split] but returns an error instead of panicking,
+// e.g. wrapping [ErrUnsatisfiableConstraint] when the required relations conflict.
+func (g Grid) TrySplit(area Rect) (GridCells, error) {
+	inner := area.Inner(g.Padding)
+
+	solver := casso.NewSolver()
+
+	rows, err := Layout{
+		Direction:   DirectionVertical,
+		Constraints: g.Rows,
+		Flex:        g.Flex,
+		Spacing:     g.RowSpacing,
+	}.configure(&solver, inner)
+	if err != nil {
+		return nil, fmt.Errorf("configure rows: %w", err)
+	}
+
+	columns, err := Layout{
+		Direction:   DirectionHorizontal,
+		Constraints: g.Columns,
+		Flex:        g.Flex,
+		Spacing:     g.ColumnSpacing,
+	}.configure(&solver, inner)
+	if err != nil {
+		return nil, fmt.Errorf("configure columns: %w", err)
+	}
+
+	if err := configureSpans(&solver, rows, columns, g.Spans, g.Flex); err != nil {
+		return nil, fmt.Errorf("configure spans: %w", err)
+	}
+
+	if err := configureRelations(&solver, gridSizes(rows.segments, columns.segments), g.Relations); err != nil {
+		return nil, fmt.Errorf("configure relations: %w", err)
+	}
+
+	changes := fetchChanges(&solver)
+
+	rowRects := changesToRects(changes, rows.segments, inner, DirectionVertical)
+	columnRects := changesToRects(changes, columns.segments, inner, DirectionHorizontal)
+
+	cells := make(GridCells, len(rowRects))
+
+	for i, row := range rowRects {
+		cells[i] = make([]Rect, len(columnRects))
+
+		for j, column := range columnRects {
+			cells[i][j] = Rect{
+				X:      column.X,
+				Y:      row.Y,
+				Width:  column.Width,
+				Height: row.Height,
+			}
+		}
+	}
+
+	return cells, nil
+}
+
+func configureSpans(
+	solver *casso.Solver,
+	rows, columns _LayoutElements,
+	spans []GridSpan,
+	flex Flex,
+) error {
+	for _, span := range spans {
+		height, err := spanElement("row", rows.segments, span.Row, span.RowSpan)
+		if err != nil {
+			return fmt.Errorf("span %+v: %w", span, err)
+		}
+
+		width, err := spanElement("column", columns.segments, span.Column, span.ColumnSpan)
+		if err != nil {
+			return fmt.Errorf("span %+v: %w", span, err)
+		}
+
+		if err := configureConstraints(solver, rows.area, []_Element{height}, []Constraint{span.Height}, flex); err != nil {
+			return fmt.Errorf("span %+v: height: %w", span, err)
+		}
+
+		if err := configureConstraints(solver, columns.area, []_Element{width}, []Constraint{span.Width}, flex); err != nil {
+			return fmt.Errorf("span %+v: width: %w", span, err)
+		}
+	}
+
+	return nil
+}
+
+// spanElement returns the element from the start of the first track to the end of the last spanned one.
+func spanElement(name string, tracks []_Element, first, span int) (_Element, error) {
+	if first < 0 || first >= len(tracks) {
+		return _Element{}, fmt.Errorf("%s %d out of range [0, %d)", name, first, len(tracks))
+	}
+
+	last := min(len(tracks), first+max(1, span)) - 1
+
+	return _Element{
+		Start: tracks[first].Start,
+		End:   tracks[last].End,
+	}, nil
+}
+
+// gridSizes resolves the sizes of the [Grid] rows and columns.
+func gridSizes(rows, columns []_Element) sizes {
+	return func(t sizeTerm) (casso.Expression, error) {
+		switch t.kind {
+		case sizeRow:
+			return elementSize("row", t.index, rows)
+		case sizeColumn:
+			return elementSize("column", t.index, columns)
+		default:
+			return casso.Expression{}, fmt.Errorf("%s is not a grid size", SizeExpr{terms: []sizeTerm{t}})
+		}
+	}
+}
+
+// GridCells is a matrix of grid cells, indexed by row and then column.
+type GridCells [][]Rect
+
+// At returns the cell at the given row and column.
+func (c GridCells) At(row, column int) Rect {
+	return c[row][column]
+}
+
+// Span returns the area of the cell at the given row and column
+// spanning the given number of rows and columns, including the spacing between them.
+//
+// Spans are clamped to the grid bounds and are at least one cell.
+func (c GridCells) Span(row, column, rowSpan, columnSpan int) Rect {
+	lastRow := min(len(c), row+max(1, rowSpan)) - 1
+	lastColumn := min(len(c[row]), column+max(1, columnSpan)) - 1
+
+	return c[row][column].Union(c[lastRow][lastColumn])
+}
+
+// Row returns the cells of the given row.
+func (c GridCells) Row(row int) []Rect {
+	return c[row]
+}
+
+// Column returns the cells of the given column.
+func (c GridCells) Column(column int) []Rect {
+	cells := make([]Rect, 0, len(c))
+
+	for _, row := range c {
+		cells = append(cells, row[column])
+	}
+
+	return cells
+}
@@ -0,0 +1,99 @@
+package bento_test
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrid_Split(t *testing.T) {
+	grid := bento.NewGrid(
+		[]bento.Constraint{bento.ConstraintLen(1), bento.ConstraintFill(1)},
+		[]bento.Constraint{bento.ConstraintLen(3), bento.ConstraintFill(1), bento.ConstraintLen(2)},
+	).WithColumnSpacing(bento.SpacingSpace(1))
+
+	cells := grid.Split(bento.Rect{X: 1, Y: 1, Width: 12, Height: 4})
+
+	require.Equal(t, bento.GridCells{
+		{
+			{X: 1, Y: 1, Width: 3, Height: 1},
+			{X: 5, Y: 1, Width: 5, Height: 1},
+			{X: 11, Y: 1, Width: 2, Height: 1},
+		},
+		{
+			{X: 1, Y: 2, Width: 3, Height: 3},
+			{X: 5, Y: 2, Width: 5, Height: 3},
+			{X: 11, Y: 2, Width: 2, Height: 3},
+		},
+	}, cells)
+
+	require.Equal(t, bento.Rect{X: 5, Y: 2, Width: 5, Height: 3}, cells.At(1, 1))
+	require.Equal(t, []bento.Rect{
+		{X: 1, Y: 1, Width: 3, Height: 1},
+		{X: 1, Y: 2, Width: 3, Height: 3},
+	}, cells.Column(0))
+}
+
+func TestGridCells_Span(t *testing.T) {
+	cells := bento.NewGrid(
+		[]bento.Constraint{bento.ConstraintLen(1), bento.ConstraintLen(1), bento.ConstraintLen(1)},
+		[]bento.Constraint{bento.ConstraintLen(2), bento.ConstraintLen(2), bento.ConstraintLen(2)},
+	).WithSpacing(bento.SpacingSpace(1)).Split(bento.Rect{Width: 8, Height: 5})
+
+	testCases := []struct {
+		name                          string
+		row, column, rowSpan, colSpan int
+		want                          bento.Rect
+	}{
+		{name: "single cell", row: 1, column: 1, rowSpan: 1, colSpan: 1, want: bento.Rect{X: 3, Y: 2, Width: 2, Height: 1}},
+		{name: "columns", row: 0, column: 0, rowSpan: 1, colSpan: 2, want: bento.Rect{X: 0, Y: 0, Width: 5, Height: 1}},
+		{name: "rows and columns", row: 0, column: 1, rowSpan: 3, colSpan: 2, want: bento.Rect{X: 3, Y: 0, Width: 5, Height: 5}},
+		{name: "clamped", row: 2, column: 2, rowSpan: 10, colSpan: 10, want: bento.Rect{X: 6, Y: 4, Width: 2, Height: 1}},
+		{name: "zero span", row: 1, column: 0, rowSpan: 0, colSpan: 0, want: bento.Rect{X: 0, Y: 2, Width: 2, Height: 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, cells.Span(tc.row, tc.column, tc.rowSpan, tc.colSpan))
+		})
+	}
+}
+
+func TestGrid_Spans(t *testing.T) {
+	grid := bento.NewGrid(
+		[]bento.Constraint{bento.ConstraintLen(1), bento.ConstraintFill(1)},
+		[]bento.Constraint{bento.ConstraintLen(2), bento.ConstraintLen(2), bento.ConstraintFill(1)},
+	)
+
+	area := bento.Rect{Width: 12, Height: 4}
+
+	cells := grid.Split(area)
+	require.Equal(t, bento.Rect{X: 0, Y: 0, Width: 4, Height: 1}, cells.Span(0, 0, 1, 2))
+
+	// the spanned columns grow to fit, and stay aligned in the other row
+	cells = grid.WithSpans(bento.NewGridSpan(0, 0, 1, 2).WithWidth(bento.ConstraintMin(8))).Split(area)
+	require.Equal(t, bento.Rect{X: 0, Y: 0, Width: 8, Height: 1}, cells.Span(0, 0, 1, 2))
+	require.Equal(t, bento.Rect{X: 8, Y: 1, Width: 4, Height: 3}, cells.At(1, 2))
+
+	_, err := grid.WithSpans(bento.NewGridSpan(3, 0, 1, 1)).TrySplit(area)
+	require.ErrorContains(t, err, "row 3 out of range")
+}
+
+func TestGrid_Relations(t *testing.T) {
+	grid := bento.NewGrid(
+		[]bento.Constraint{bento.ConstraintLen(2), bento.ConstraintFill(1)},
+		[]bento.Constraint{bento.ConstraintFill(1), bento.ConstraintFill(1)},
+	).WithRelations(bento.ColumnSize(0).Equal(bento.RowSize(0).Mul(2)))
+
+	cells := grid.Split(bento.Rect{Width: 12, Height: 6})
+
+	require.Equal(t, bento.Rect{X: 0, Y: 0, Width: 4, Height: 2}, cells.At(0, 0))
+	require.Equal(t, bento.Rect{X: 4, Y: 2, Width: 8, Height: 4}, cells.At(1, 1))
+
+	_, err := grid.WithRelations(bento.SegmentSize(0).Equal(bento.ConstSize(1))).TrySplit(bento.Rect{Width: 12, Height: 6})
+	require.ErrorContains(t, err, "Segment(0) is not a grid size")
+
+	_, err = grid.WithRelations(bento.ColumnSize(0).Equal(bento.ConstSize(30))).TrySplit(bento.Rect{Width: 12, Height: 6})
+	require.ErrorIs(t, err, bento.ErrUnsatisfiableConstraint)
+}
@@ -0,0 +1,202 @@
+package helpwidget
+
+import (
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/blockwidget"
+	"github.com/metafates/bento/keymap"
+	"github.com/rivo/uniseg"
+)
+
+var _ bento.Widget = (*Help)(nil)
+
+// Help shows the enabled key bindings of a key map.
+//
+// The short help is a single line of the [keymap.KeyMap.ShortHelp] bindings,
+// the full help shows the [keymap.KeyMap.FullHelp] groups in columns.
+type Help struct {
+	block          *blockwidget.Block
+	keyMap         keymap.KeyMap
+	showAll        bool
+	style          bento.Style
+	keyStyle       bento.Style
+	descStyle      bento.Style
+	separatorStyle bento.Style
+	shortSeparator string
+	fullSeparator  string
+	ellipsis       string
+}
+
+func New(keyMap keymap.KeyMap) Help {
+	return Help{
+		block:          nil,
+		keyMap:         keyMap,
+		showAll:        false,
+		style:          bento.NewStyle(),
+		keyStyle:       bento.NewStyle(),
+		descStyle:      bento.NewStyle().Dim(),
+		separatorStyle: bento.NewStyle().Dim(),
+		shortSeparator: " • ",
+		fullSeparator:  "    ",
+		ellipsis:       "…",
+	}
+}
+
+func (h Help) WithBlock(block blockwidget.Block) Help {
+	h.block = &block
+	return h
+}
+
+func (h Help) WithKeyMap(keyMap keymap.KeyMap) Help {
+	h.keyMap = keyMap
+	return h
+}
+
+// WithShowAll shows the full help instead of the short one.
+func (h Help) WithShowAll(showAll bool) Help {
+	h.showAll = showAll
+	return h
+}
+
+func (h Help) WithStyle(style bento.Style) Help {
+	h.style = style
+	return h
+}
+
+func (h Help) WithKeyStyle(style bento.Style) Help {
+	h.keyStyle = style
+	return h
+}
+
+func (h Help) WithDescStyle(style bento.Style) Help {
+	h.descStyle = style
+	return h
+}
+
+func (h Help) WithSeparatorStyle(style bento.Style) Help {
+	h.separatorStyle = style
+	return h
+}
+
+// WithShortSeparator sets the separator between the bindings of the short help.
+func (h Help) WithShortSeparator(separator string) Help {
+	h.shortSeparator = separator
+	return h
+}
+
+// WithFullSeparator sets the separator between the columns of the full help.
+func (h Help) WithFullSeparator(separator string) Help {
+	h.fullSeparator = separator
+	return h
+}
+
+// WithEllipsis sets the symbol shown when the short help does not fit.
+func (h Help) WithEllipsis(ellipsis string) Help {
+	h.ellipsis = ellipsis
+	return h
+}
+
+func (h Help) Render(area bento.Rect, buffer *bento.Buffer) {
+	buffer.SetStyle(area, h.style)
+
+	if h.block != nil {
+		h.block.Render(area, buffer)
+		area = h.block.Inner(area)
+	}
+
+	if area.IsEmpty() || h.keyMap == nil {
+		return
+	}
+
+	if h.showAll {
+		h.renderFull(area, buffer)
+	} else {
+		h.renderShort(area, buffer)
+	}
+}
+
+func (h Help) renderShort(area bento.Rect, buffer *bento.Buffer) {
+	bindings := enabled(h.keyMap.ShortHelp())
+
+	x := area.X
+
+	for i, binding := range bindings {
+		help := binding.Help()
+
+		var separator string
+		if i > 0 {
+			separator = h.shortSeparator
+		}
+
+		width := uniseg.StringWidth(separator) + uniseg.StringWidth(help.Key) + 1 + uniseg.StringWidth(help.Desc)
+
+		// leave room for the ellipsis unless it is the last binding
+		reserved := 0
+		if i < len(bindings)-1 {
+			reserved = uniseg.StringWidth(h.ellipsis) + 1
+		}
+
+		if x+width+reserved > area.Right() {
+			if x+1+uniseg.StringWidth(h.ellipsis) <= area.Right() {
+				buffer.SetString(x+1, area.Y, h.ellipsis, h.separatorStyle)
+			}
+
+			return
+		}
+
+		x, _ = buffer.SetStringN(x, area.Y, separator, area.Right()-x, h.separatorStyle)
+		x, _ = buffer.SetStringN(x, area.Y, help.Key, area.Right()-x, h.keyStyle)
+		x++
+		x, _ = buffer.SetStringN(x, area.Y, help.Desc, area.Right()-x, h.descStyle)
+	}
+}
+
+func (h Help) renderFull(area bento.Rect, buffer *bento.Buffer) {
+	x := area.X
+
+	for _, group := range h.keyMap.FullHelp() {
+		bindings := enabled(group)
+		if len(bindings) == 0 {
+			continue
+		}
+
+		if x > area.X {
+			x, _ = buffer.SetStringN(x, area.Y, h.fullSeparator, area.Right()-x, h.separatorStyle)
+		}
+
+		if x >= area.Right() {
+			return
+		}
+
+		var keyWidth, descWidth int
+
+		for _, binding := range bindings {
+			keyWidth = max(keyWidth, uniseg.StringWidth(binding.Help().Key))
+			descWidth = max(descWidth, uniseg.StringWidth(binding.Help().Desc))
+		}
+
+		for i, binding := range bindings[:min(len(bindings), area.Height)] {
+			y := area.Y + i
+
+			buffer.SetStringN(x, y, binding.Help().Key, area.Right()-x, h.keyStyle)
+
+			if descX := x + keyWidth + 1; descX < area.Right() {
+				buffer.SetStringN(descX, y, binding.Help().Desc, area.Right()-descX, h.descStyle)
+			}
+		}
+
+		x += keyWidth + 1 + descWidth
+	}
+}
+
+// enabled returns the enabled bindings.
+func enabled(bindings []keymap.Binding) []keymap.Binding {
+	result := make([]keymap.Binding, 0, len(bindings))
+
+	for _, binding := range bindings {
+		if binding.Enabled() {
+			result = append(result, binding)
+		}
+	}
+
+	return result
+}
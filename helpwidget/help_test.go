@@ -0,0 +1,63 @@
+package helpwidget
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/metafates/bento/keymap"
+)
+
+type testKeyMap struct {
+	up, down, quit, hidden keymap.Binding
+}
+
+func newTestKeyMap() testKeyMap {
+	return testKeyMap{
+		up:     keymap.NewBinding("k", "up").WithHelp("↑/k", "up"),
+		down:   keymap.NewBinding("j", "down").WithHelp("↓/j", "down"),
+		quit:   keymap.NewBinding("q").WithHelp("q", "quit"),
+		hidden: keymap.NewBinding("x").WithHelp("x", "hidden").WithEnabled(false),
+	}
+}
+
+func (k testKeyMap) ShortHelp() []keymap.Binding {
+	return []keymap.Binding{k.up, k.down, k.hidden, k.quit}
+}
+
+func (k testKeyMap) FullHelp() [][]keymap.Binding {
+	return [][]keymap.Binding{{k.up, k.down}, {k.hidden}, {k.quit}}
+}
+
+func widget(help Help, width, height int) bento.Buffer {
+	buffer := bento.NewBufferEmpty(bento.Rect{Width: width, Height: height})
+
+	help.Render(buffer.Area(), &buffer)
+
+	return buffer
+}
+
+func TestHelp_Short(t *testing.T) {
+	help := New(newTestKeyMap())
+
+	bentotest.AssertBufferLines(t, []string{
+		"↑/k up • ↓/j down • q quit ",
+	}, widget(help, 27, 1))
+
+	bentotest.AssertBufferLines(t, []string{
+		"↑/k up • ↓/j down …",
+	}, widget(help, 19, 1))
+
+	bentotest.AssertBufferLines(t, []string{
+		"↑/k up …    ",
+	}, widget(help, 12, 1))
+}
+
+func TestHelp_Full(t *testing.T) {
+	help := New(newTestKeyMap()).WithShowAll(true).WithFullSeparator("  ")
+
+	bentotest.AssertBufferLines(t, []string{
+		"↑/k up    q quit",
+		"↓/j down        ",
+	}, widget(help, 16, 2))
+}
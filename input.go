@@ -52,12 +52,29 @@ func (k KeyMsg) String() (str string) {
 	return Key(k).String()
 }
 
+// PasteMsg contains the text pasted while bracketed paste is enabled, see [App.WithoutBracketedPaste].
+// The text is passed as is, including the newlines, so it never triggers key bindings.
+//
+// Pastes longer than the maximum size set with [App.WithMaxPasteSize] are truncated.
+type PasteMsg string
+
 // Key contains information about a keypress.
 type Key struct {
 	Type  KeyType
 	Runes []rune
 	Alt   bool
+
+	// Deprecated: pastes are reported as [PasteMsg].
 	Paste bool
+
+	// Mod are the modifiers held while the key was pressed.
+	// They are only reported by terminals supporting the kitty keyboard protocol,
+	// see [App.WithKeyboardEnhancement].
+	Mod KeyMod
+
+	// Event is the kind of the key event.
+	// Repeat events are only reported with [KeyboardReportEventTypes].
+	Event KeyEventType
 }
 
 // String returns a friendly string representation for a key. It's safe (and
@@ -67,6 +84,10 @@ type Key struct {
 //	fmt.Println(k)
 //	// Output: enter
 func (k Key) String() (str string) {
+	if k.Mod != 0 {
+		return k.modString()
+	}
+
 	var buf strings.Builder
 	if k.Alt {
 		buf.WriteString("alt+")
@@ -557,9 +578,13 @@ var spaceRunes = []rune{' '}
 
 // readAnsiInputs reads keypress and mouse inputs from a TTY and produces messages
 // containing information about the key or mouse events accordingly.
-func readAnsiInputs(ctx context.Context, msgs chan<- Msg, input io.Reader) error {
+//
+// Pastes larger than maxPasteSize bytes are truncated, unless it is zero or negative.
+func readAnsiInputs(ctx context.Context, msgs chan<- Msg, input io.Reader, maxPasteSize int) error {
 	var buf [256]byte
 
+	paste := pasteReader{maxSize: maxPasteSize}
+
 	var leftOverFromPrevIteration []byte
 loop:
 	for {
@@ -585,7 +610,11 @@ loop:
 		var i, w int
 		for i, w = 0, 0; i < len(b); i += w {
 			var msg Msg
-			w, msg = detectOneMsg(b[i:], canHaveMoreData)
+			if paste.active {
+				w, msg = paste.read(b[i:])
+			} else {
+				w, msg = detectOneMsg(b[i:], canHaveMoreData)
+			}
 			if w == 0 {
 				// Expecting more bytes beyond the current buffer. Try waiting
 				// for more input.
@@ -594,6 +623,15 @@ loop:
 				continue loop
 			}
 
+			switch msg.(type) {
+			case nil:
+				// the paste continues
+				continue
+			case pasteStartMsg:
+				paste.active = true
+				continue
+			}
+
 			select {
 			case msgs <- msg:
 			case <-ctx.Done():
@@ -644,6 +682,13 @@ func detectOneMsg(b []byte, canHaveMoreData bool) (w int, msg Msg) {
 		return w, msg
 	}
 
+	// Detect kitty keyboard protocol key events.
+	var foundKey bool
+	foundKey, w, msg = detectKittyKey(b)
+	if foundKey {
+		return w, msg
+	}
+
 	// Detect escape sequence and control characters other than NUL,
 	// possibly with an escape character in front to mark the Alt
 	// modifier.
@@ -717,59 +762,97 @@ func detectOneMsg(b []byte, canHaveMoreData bool) (w int, msg Msg) {
 }
 
 // detectReportFocus detects a focus report sequence.
+//
+// Blur report shares the prefix with some of the keys sequences (e.g. "\x1b[OA"),
+// so it is not reported if the input continues with such a sequence.
 // nolint: gomnd
 func detectReportFocus(input []byte) (hasRF bool, width int, msg Msg) {
+	const (
+		focus = "\x1b[I"
+		blur  = "\x1b[O"
+	)
+
 	switch {
-	case bytes.Equal(input, []byte("\x1b[I")):
-		return true, 3, FocusMsg{}
-	case bytes.Equal(input, []byte("\x1b[O")):
-		return true, 3, BlurMsg{}
+	case bytes.HasPrefix(input, []byte(focus)):
+		return true, len(focus), FocusMsg{}
+	case bytes.HasPrefix(input, []byte(blur)):
+		if len(input) > len(blur) {
+			if _, ok := sequences[string(input[:len(blur)+1])]; ok {
+				return false, 0, nil
+			}
+		}
+
+		return true, len(blur), BlurMsg{}
 	}
 	return false, 0, nil
 }
 
-// detectBracketedPaste detects an input pasted while bracketed
+const (
+	bracketedPasteStart = "\x1b[200~"
+	bracketedPasteEnd   = "\x1b[201~"
+)
+
+// pasteStartMsg is reported by detectOneMsg at the start of a bracketed paste.
+// The input is then read by the pasteReader until the end of the paste.
+type pasteStartMsg struct{}
+
+// detectBracketedPaste detects the start of an input pasted while bracketed
 // paste mode was enabled.
 //
 // Note: this function is a no-op if bracketed paste was not enabled
 // on the terminal, since in that case we'd never see this
 // particular escape sequence.
 func detectBracketedPaste(input []byte) (hasBp bool, width int, msg Msg) {
-	// Detect the start sequence.
-	const bpStart = "\x1b[200~"
-	if len(input) < len(bpStart) || string(input[:len(bpStart)]) != bpStart {
+	if !bytes.HasPrefix(input, []byte(bracketedPasteStart)) {
 		return false, 0, nil
 	}
 
-	// Skip over the start sequence.
-	input = input[len(bpStart):]
-
-	// If we saw the start sequence, then we must have an end sequence
-	// as well. Find it.
-	const bpEnd = "\x1b[201~"
-	idx := bytes.Index(input, []byte(bpEnd))
-	inputLen := len(bpStart) + idx + len(bpEnd)
-	if idx == -1 {
-		// We have encountered the end of the input buffer without seeing
-		// the marker for the end of the bracketed paste.
-		// Tell the outer loop we have done a short read and we want more.
-		return true, 0, nil
+	return true, len(bracketedPasteStart), pasteStartMsg{}
+}
+
+// pasteReader reads a bracketed paste, which may span many reads.
+// Only the first maxSize bytes are kept, so that huge pastes don't exhaust the memory.
+type pasteReader struct {
+	active  bool
+	maxSize int
+	text    []byte
+}
+
+// read consumes the pasted input until the end of the paste,
+// when it returns the [PasteMsg]. Otherwise the returned message is nil.
+func (p *pasteReader) read(input []byte) (w int, msg Msg) {
+	if idx := bytes.Index(input, []byte(bracketedPasteEnd)); idx >= 0 {
+		p.append(input[:idx])
+
+		msg = PasteMsg(strings.ToValidUTF8(string(p.text), ""))
+
+		p.active = false
+		p.text = nil
+
+		return idx + len(bracketedPasteEnd), msg
 	}
 
-	// The paste is everything in-between.
-	paste := input[:idx]
+	// The end sequence may be split between reads, keep its beginning for the next one.
+	w = len(input)
 
-	// All there is in-between is runes, not to be interpreted further.
-	k := Key{Type: KeyRunes, Paste: true}
-	for len(paste) > 0 {
-		r, w := utf8.DecodeRune(paste)
-		if r != utf8.RuneError {
-			k.Runes = append(k.Runes, r)
+	for n := min(len(input), len(bracketedPasteEnd)-1); n > 0; n-- {
+		if bytes.HasSuffix(input, []byte(bracketedPasteEnd[:n])) {
+			w -= n
+			break
 		}
-		paste = paste[w:]
 	}
 
-	return true, inputLen, KeyMsg(k)
+	p.append(input[:w])
+
+	return w, nil
+}
+
+func (p *pasteReader) append(b []byte) {
+	if p.maxSize > 0 {
+		b = b[:min(len(b), max(0, p.maxSize-len(p.text)))]
+	}
+
+	p.text = append(p.text, b...)
 }
 
 // seqLengths is the sizes of valid sequences, starting with the
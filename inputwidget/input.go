@@ -18,6 +18,7 @@ type Input struct {
 	placeholder      grapheme.Graphemes
 	placeholderStyle bento.Style
 	cursorStyle      bento.Style
+	cursorShape      *bento.CursorShape
 	vertical         bento.Flex
 	prompt           string
 	promptStyle      bento.Style
@@ -31,6 +32,7 @@ func New() Input {
 		placeholder:      nil,
 		placeholderStyle: bento.NewStyle().Dim().Italic(),
 		cursorStyle:      bento.NewStyle().Reversed(),
+		cursorShape:      nil,
 		vertical:         bento.FlexLegacy,
 		prompt:           "",
 		promptStyle:      bento.NewStyle(),
@@ -96,6 +98,15 @@ func (i Input) WithCursorStyle(style bento.Style) Input {
 	return i
 }
 
+// WithTerminalCursor makes the input place the real terminal cursor with the given shape
+// instead of highlighting the cursor cell with the cursor style.
+//
+// This lets input method editors and screen readers know where the text is being edited.
+func (i Input) WithTerminalCursor(shape bento.CursorShape) Input {
+	i.cursorShape = &shape
+	return i
+}
+
 func (i Input) WithAlignment(alignment bento.Alignment) Input {
 	i.alignment = alignment
 	return i
@@ -130,7 +141,9 @@ func (i Input) RenderStateful(area bento.Rect, buffer *bento.Buffer, state *Stat
 	before, cursor, after := i.split(tempState)
 
 	if state.showCursor {
-		cursor = cursor.WithStylePatch(i.cursorStyle)
+		if i.cursorShape == nil {
+			cursor = cursor.WithStylePatch(i.cursorStyle)
+		}
 
 		if cursor.Content == "" {
 			cursor.Content = " "
@@ -151,6 +164,35 @@ func (i Input) RenderStateful(area bento.Rect, buffer *bento.Buffer, state *Stat
 	).WithAlignment(i.alignment)
 
 	line.Render(area, buffer)
+
+	if state.showCursor && i.cursorShape != nil {
+		i.setTerminalCursor(area, buffer, line, prompt.Width()+before.Width())
+	}
+}
+
+// setTerminalCursor requests the terminal cursor at the given offset of the rendered line.
+func (i Input) setTerminalCursor(area bento.Rect, buffer *bento.Buffer, line textwidget.Line, offset int) {
+	area = area.Intersection(buffer.Area())
+	if area.IsEmpty() {
+		return
+	}
+
+	var indent int
+
+	// mirrors the line alignment
+	if lineWidth := line.Width(); lineWidth <= area.Width {
+		switch i.alignment {
+		case bento.AlignmentCenter:
+			indent = (area.Width - lineWidth) / 2
+		case bento.AlignmentRight:
+			indent = area.Width - lineWidth
+		}
+	}
+
+	x := min(area.Right()-1, area.X+indent+offset)
+
+	buffer.SetCursorPosition(bento.Position{X: x, Y: area.Y})
+	buffer.SetCursorShape(*i.cursorShape)
 }
 
 func (i Input) split(state State) (beforeSpan, cursorSpan, afterSpan textwidget.Span) {
@@ -0,0 +1,40 @@
+package inputwidget
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput_TerminalCursor(t *testing.T) {
+	state := NewState()
+	state.Append("hello")
+	state.MoveCursorLeft()
+
+	input := New().
+		WithPrompt("> ").
+		WithTerminalCursor(bento.CursorShapeSteadyBar)
+
+	buffer := bento.NewBufferEmpty(bento.NewRect(10, 1))
+	input.RenderStateful(buffer.Area(), &buffer, &state)
+
+	position, ok := buffer.CursorPosition()
+	require.True(t, ok)
+	require.Equal(t, bento.Position{X: 6, Y: 0}, position)
+	require.Equal(t, bento.CursorShapeSteadyBar, buffer.CursorShape())
+
+	// the cursor cell is not highlighted
+	cell := buffer.CellAt(position)
+	require.Equal(t, "o", cell.Symbol)
+	require.Zero(t, cell.Modifier)
+
+	// a hidden cursor is not placed
+	state.ShowCursor(false)
+
+	buffer = bento.NewBufferEmpty(bento.NewRect(10, 1))
+	input.RenderStateful(buffer.Area(), &buffer, &state)
+
+	_, ok = buffer.CursorPosition()
+	require.False(t, ok)
+}
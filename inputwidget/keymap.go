@@ -0,0 +1,53 @@
+package inputwidget
+
+import "github.com/metafates/bento/keymap"
+
+var _ keymap.KeyMap = (*KeyMap)(nil)
+
+// KeyMap is the key bindings of the input [State].
+// Typed text is inserted regardless of the bindings.
+type KeyMap struct {
+	MoveLeft       keymap.Binding
+	MoveRight      keymap.Binding
+	MoveWordLeft   keymap.Binding
+	MoveWordRight  keymap.Binding
+	MoveBegin      keymap.Binding
+	MoveEnd        keymap.Binding
+	DeleteBackward keymap.Binding
+	DeleteWord     keymap.Binding
+	DeleteLine     keymap.Binding
+	Yank           keymap.Binding
+	YankPop        keymap.Binding
+	Undo           keymap.Binding
+	Redo           keymap.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		MoveLeft:       keymap.NewBinding("left").WithHelp("←", "left"),
+		MoveRight:      keymap.NewBinding("right").WithHelp("→", "right"),
+		MoveWordLeft:   keymap.NewBinding("shift+left").WithHelp("shift+←", "word left"),
+		MoveWordRight:  keymap.NewBinding("shift+right").WithHelp("shift+→", "word right"),
+		MoveBegin:      keymap.NewBinding("ctrl+a").WithHelp("ctrl+a", "line start"),
+		MoveEnd:        keymap.NewBinding("ctrl+e").WithHelp("ctrl+e", "line end"),
+		DeleteBackward: keymap.NewBinding("backspace", "delete").WithHelp("backspace", "delete"),
+		DeleteWord:     keymap.NewBinding("ctrl+w").WithHelp("ctrl+w", "delete word"),
+		DeleteLine:     keymap.NewBinding("ctrl+u").WithHelp("ctrl+u", "delete line"),
+		Yank:           keymap.NewBinding("ctrl+y").WithHelp("ctrl+y", "yank"),
+		YankPop:        keymap.NewBinding("alt+y").WithHelp("alt+y", "yank previous"),
+		Undo:           keymap.NewBinding("ctrl+z").WithHelp("ctrl+z", "undo"),
+		Redo:           keymap.NewBinding("alt+z", "ctrl+shift+z").WithHelp("alt+z", "redo"),
+	}
+}
+
+func (k KeyMap) ShortHelp() []keymap.Binding {
+	return []keymap.Binding{k.DeleteWord, k.Undo}
+}
+
+func (k KeyMap) FullHelp() [][]keymap.Binding {
+	return [][]keymap.Binding{
+		{k.MoveLeft, k.MoveRight, k.MoveWordLeft, k.MoveWordRight, k.MoveBegin, k.MoveEnd},
+		{k.DeleteBackward, k.DeleteWord, k.DeleteLine},
+		{k.Yank, k.YankPop, k.Undo, k.Redo},
+	}
+}
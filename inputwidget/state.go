@@ -2,13 +2,36 @@ package inputwidget
 
 import (
 	"slices"
+	"strings"
 
 	"github.com/metafates/bento"
 	"github.com/metafates/bento/internal/grapheme"
 	"github.com/metafates/bento/internal/sliceutil"
+	"github.com/metafates/bento/keymap"
 	"github.com/rivo/uniseg"
 )
 
+// maxHistory is the number of edits kept for undo.
+const maxHistory = 100
+
+// maxKillRing is the number of deletions kept for yank.
+const maxKillRing = 16
+
+type editKind int
+
+const (
+	editNone editKind = iota
+	editInsert
+	editDelete
+	editKill
+	editYank
+)
+
+type snapshot struct {
+	graphemes grapheme.Graphemes
+	cursor    int
+}
+
 type State struct {
 	cursor     int
 	showCursor bool
@@ -16,6 +39,19 @@ type State struct {
 	graphemes grapheme.Graphemes
 
 	offset int
+
+	undo     []snapshot
+	redo     []snapshot
+	lastEdit editKind
+
+	killRing []string
+
+	// yankStart and yankIndex describe the last yank, the text from yankStart to the cursor
+	// is the kill ring entry at yankIndex.
+	yankStart int
+	yankIndex int
+
+	keyMap *KeyMap
 }
 
 func NewState() State {
@@ -24,6 +60,19 @@ func NewState() State {
 	}
 }
 
+// KeyMap returns the key bindings of the state, [DefaultKeyMap] unless set with [State.SetKeyMap].
+func (s *State) KeyMap() KeyMap {
+	if s.keyMap == nil {
+		return DefaultKeyMap()
+	}
+
+	return *s.keyMap
+}
+
+func (s *State) SetKeyMap(keyMap KeyMap) {
+	s.keyMap = &keyMap
+}
+
 func (s *State) String() string {
 	return s.graphemes.String()
 }
@@ -38,36 +87,69 @@ func (s *State) ShowCursor(show bool) {
 }
 
 func (s *State) MoveCursorLeft() {
+	s.lastEdit = editNone
 	s.setCursor(s.cursor - 1)
 }
 
 func (s *State) MoveCursorRight() {
+	s.lastEdit = editNone
 	s.setCursor(s.cursor + 1)
 }
 
 func (s *State) MoveCursorBegin() {
+	s.lastEdit = editNone
 	s.setCursor(0)
 	s.offset = 0
 }
 
 func (s *State) MoveCursorEnd() {
+	s.lastEdit = editNone
 	s.setCursor(len(s.graphemes))
 }
 
+// Append inserts the content at the cursor.
+// Consecutive appends are undone at once.
 func (s *State) Append(content string) {
+	if content == "" {
+		return
+	}
+
+	s.edit(editInsert)
+	s.insert(content)
+}
+
+// Paste inserts the text as a separate undo step.
+// The line breaks are replaced with spaces.
+func (s *State) Paste(text string) {
+	text = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(text)
+
+	s.lastEdit = editNone
+	s.Append(text)
+	s.lastEdit = editNone
+}
+
+func (s *State) insert(content string) {
 	graphemes := uniseg.NewGraphemes(content)
 
 	for graphemes.Next() {
 		s.graphemes = slices.Insert(s.graphemes, s.cursor, grapheme.New(graphemes.Str()))
-		s.MoveCursorRight()
+		s.setCursor(s.cursor + 1)
 	}
 }
 
+// DeleteLine deletes the whole line into the kill ring.
 func (s *State) DeleteLine() {
-	s.graphemes = nil
-	s.cursor = 0
+	if len(s.graphemes) == 0 {
+		return
+	}
+
+	s.killWith(func() {
+		s.graphemes = nil
+		s.cursor = 0
+	})
 }
 
+// DeleteWordUnderCursor deletes the word before the cursor into the kill ring.
 func (s *State) DeleteWordUnderCursor() {
 	current := s.underCursor()
 
@@ -76,6 +158,12 @@ func (s *State) DeleteWordUnderCursor() {
 		return
 	}
 
+	s.killWith(s.deleteWordUnderCursor)
+}
+
+func (s *State) deleteWordUnderCursor() {
+	current := s.underCursor()
+
 	if current.IsWhitespace() {
 		s.deleteWhile(func(g grapheme.Grapheme) bool { return g.IsWhitespace() })
 	}
@@ -83,85 +171,218 @@ func (s *State) DeleteWordUnderCursor() {
 	s.deleteWhile(func(g grapheme.Grapheme) bool { return !g.IsEmpty() && !g.IsWhitespace() })
 }
 
+// deleteWhile deletes the graphemes before the cursor while the condition holds.
 func (s *State) deleteWhile(cond func(g grapheme.Grapheme) bool) {
-	before, under, after := s.splitAtCursor()
-
-	current := under
+	start := s.cursor
 
-	for cond(current) {
-		if len(before) == 0 {
-			current = grapheme.Grapheme{}
-			break
-		}
-		s.MoveCursorLeft()
-
-		current = before[len(before)-1]
-		before = before[:len(before)-1]
+	for start > 0 && cond(s.graphemes[start-1]) {
+		start--
 	}
 
-	s.graphemes = append(before, current)
-	s.graphemes = append(s.graphemes, after...)
+	s.graphemes = slices.Delete(s.graphemes, start, s.cursor)
+	s.setCursor(start)
 }
 
+// DeleteUnderCursor deletes the grapheme before the cursor.
+// Consecutive deletions are undone at once.
 func (s *State) DeleteUnderCursor() {
+	if s.cursor == 0 {
+		return
+	}
+
+	s.edit(editDelete)
+
 	before, _, after := s.splitAtCursor()
 
 	s.graphemes = append(before, after...)
-	s.MoveCursorLeft()
+	s.setCursor(s.cursor - 1)
 }
 
+// MoveWordRight moves the cursor to the end of the next word.
 func (s *State) MoveWordRight() {
-	// TODO
+	s.lastEdit = editNone
+	s.setCursor(s.graphemes.NextWordEnd(s.cursor))
 }
 
+// MoveWordLeft moves the cursor to the start of the previous word.
 func (s *State) MoveWordLeft() {
-	// TODO
+	s.lastEdit = editNone
+	s.setCursor(s.graphemes.PrevWordStart(s.cursor))
+}
+
+// edit records the state before the edit of the given kind for undo.
+// Consecutive inserts or deletes are grouped into a single step.
+func (s *State) edit(kind editKind) {
+	s.redo = nil
+
+	if kind == s.lastEdit && (kind == editInsert || kind == editDelete) {
+		return
+	}
+
+	s.undo = append(s.undo, s.snapshot())
+	if len(s.undo) > maxHistory {
+		s.undo = s.undo[1:]
+	}
+
+	s.lastEdit = kind
+}
+
+func (s *State) snapshot() snapshot {
+	return snapshot{graphemes: slices.Clone(s.graphemes), cursor: s.cursor}
+}
+
+func (s *State) restore(snap snapshot) {
+	s.graphemes = snap.graphemes
+	s.cursor = snap.cursor
+	s.lastEdit = editNone
+}
+
+// Undo reverts the last edit.
+func (s *State) Undo() {
+	if len(s.undo) == 0 {
+		return
+	}
+
+	s.redo = append(s.redo, s.snapshot())
+	s.restore(s.undo[len(s.undo)-1])
+	s.undo = s.undo[:len(s.undo)-1]
+}
+
+// Redo reapplies the last undone edit.
+func (s *State) Redo() {
+	if len(s.redo) == 0 {
+		return
+	}
+
+	s.undo = append(s.undo, s.snapshot())
+	s.restore(s.redo[len(s.redo)-1])
+	s.redo = s.redo[:len(s.redo)-1]
+}
+
+// killWith runs the deletion and saves the deleted text to the kill ring.
+// Consecutive kills are joined into a single entry, like in emacs.
+func (s *State) killWith(deletion func()) {
+	consecutive := s.lastEdit == editKill
+
+	s.edit(editKill)
+
+	before := slices.Clone(s.graphemes)
+
+	deletion()
+
+	killed := before[s.cursor : s.cursor+len(before)-len(s.graphemes)].String()
+
+	if consecutive && len(s.killRing) > 0 {
+		s.killRing[len(s.killRing)-1] = killed + s.killRing[len(s.killRing)-1]
+		return
+	}
+
+	s.killRing = append(s.killRing, killed)
+	if len(s.killRing) > maxKillRing {
+		s.killRing = s.killRing[1:]
+	}
+}
+
+// Yank inserts the last killed text at the cursor.
+func (s *State) Yank() {
+	if len(s.killRing) == 0 {
+		return
+	}
+
+	s.edit(editYank)
+
+	s.yankStart = s.cursor
+	s.yankIndex = len(s.killRing) - 1
+
+	s.insert(s.killRing[s.yankIndex])
+}
+
+// YankPop replaces the text just yanked with the previous kill ring entry.
+// It does nothing if the last edit was not a yank.
+func (s *State) YankPop() {
+	if s.lastEdit != editYank || len(s.killRing) == 0 {
+		return
+	}
+
+	s.graphemes = slices.Delete(s.graphemes, s.yankStart, s.cursor)
+	s.cursor = s.yankStart
+
+	s.yankIndex = (s.yankIndex - 1 + len(s.killRing)) % len(s.killRing)
+
+	s.insert(s.killRing[s.yankIndex])
 }
 
 func (s *State) TryUpdate(msg bento.Msg) (bool, bento.Cmd) {
-	keyMsg, ok := msg.(bento.KeyMsg)
-	if !ok {
+	switch msg := msg.(type) {
+	case bento.KeyMsg:
+		return s.update(bento.Key(msg)), nil
+
+	case bento.PasteMsg:
+		s.Paste(string(msg))
+		return true, nil
+
+	default:
 		return false, nil
 	}
-
-	return s.update(bento.Key(keyMsg)), nil
 }
 
 func (s *State) update(key bento.Key) bool {
-	switch key.Type {
-	case bento.KeyLeft:
+	keyMap := s.KeyMap()
+
+	switch {
+	case keymap.Matches(key, keyMap.Redo):
+		s.Redo()
+		return true
+
+	case keymap.Matches(key, keyMap.YankPop):
+		s.YankPop()
+		return true
+
+	case keymap.Matches(key, keyMap.MoveLeft):
 		s.MoveCursorLeft()
 		return true
 
-	case bento.KeyShiftLeft:
+	case keymap.Matches(key, keyMap.MoveWordLeft):
 		s.MoveWordLeft()
 		return true
 
-	case bento.KeyRight:
+	case keymap.Matches(key, keyMap.MoveRight):
 		s.MoveCursorRight()
 		return true
 
-	case bento.KeyShiftRight:
+	case keymap.Matches(key, keyMap.MoveWordRight):
 		s.MoveWordRight()
 		return true
 
-	case bento.KeyBackspace, bento.KeyDelete:
+	case keymap.Matches(key, keyMap.DeleteBackward):
 		s.DeleteUnderCursor()
 		return true
 
-	case bento.KeyCtrlA:
+	case keymap.Matches(key, keyMap.MoveBegin):
 		s.MoveCursorBegin()
 		return true
 
-	case bento.KeyCtrlE:
+	case keymap.Matches(key, keyMap.MoveEnd):
 		s.MoveCursorEnd()
 		return true
 
-	case bento.KeyCtrlW:
+	case keymap.Matches(key, keyMap.DeleteWord):
 		s.DeleteWordUnderCursor()
 		return true
 
-	case bento.KeyRunes, bento.KeySpace:
+	case keymap.Matches(key, keyMap.DeleteLine):
+		s.DeleteLine()
+		return true
+
+	case keymap.Matches(key, keyMap.Yank):
+		s.Yank()
+		return true
+
+	case keymap.Matches(key, keyMap.Undo):
+		s.Undo()
+		return true
+
+	case key.Type == bento.KeyRunes, key.Type == bento.KeySpace:
 		s.Append(string(key.Runes))
 		return true
 
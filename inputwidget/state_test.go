@@ -0,0 +1,108 @@
+package inputwidget
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+func send(t *testing.T, state *State, keys ...bento.KeyMsg) {
+	t.Helper()
+
+	for _, key := range keys {
+		handled, _ := state.TryUpdate(key)
+		require.True(t, handled, "key %q", key.String())
+	}
+}
+
+func typed(s string) []bento.KeyMsg {
+	var keys []bento.KeyMsg
+
+	for _, r := range s {
+		keys = append(keys, bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune{r}})
+	}
+
+	return keys
+}
+
+func key(keyType bento.KeyType) bento.KeyMsg {
+	return bento.KeyMsg{Type: keyType}
+}
+
+func TestState_UndoRedo(t *testing.T) {
+	state := NewState()
+
+	send(t, &state, typed("hello")...)
+	send(t, &state, key(bento.KeyLeft))
+	send(t, &state, typed("X")...)
+	require.Equal(t, "hellXo", state.String())
+
+	send(t, &state, key(bento.KeyBackspace), key(bento.KeyBackspace))
+	require.Equal(t, "helo", state.String())
+
+	// the deletions, the insertion after the move and the typing before it
+	send(t, &state, key(bento.KeyCtrlZ))
+	require.Equal(t, "hellXo", state.String())
+
+	send(t, &state, key(bento.KeyCtrlZ))
+	require.Equal(t, "hello", state.String())
+
+	send(t, &state, key(bento.KeyCtrlZ))
+	require.Equal(t, "", state.String())
+
+	send(t, &state, bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune{'z'}, Alt: true})
+	require.Equal(t, "hello", state.String())
+
+	// with the kitty keyboard protocol
+	send(t, &state, bento.KeyMsg{Type: bento.KeyCtrlZ, Runes: []rune{'z'}, Mod: bento.ModCtrl | bento.ModShift})
+	require.Equal(t, "hellXo", state.String())
+
+	// a new edit drops the redo history
+	state.Append("!")
+	state.Redo()
+	require.Equal(t, "hellX!o", state.String())
+}
+
+func TestState_KillRing(t *testing.T) {
+	state := NewState()
+	state.Append("foo bar baz")
+
+	send(t, &state, key(bento.KeyCtrlW))
+	require.Equal(t, "foo bar ", state.String())
+
+	state.MoveCursorLeft()
+
+	// consecutive kills are joined
+	send(t, &state, key(bento.KeyCtrlW), key(bento.KeyCtrlW))
+	require.Equal(t, " ", state.String())
+	require.Equal(t, []string{"baz", "foo bar"}, state.killRing)
+
+	send(t, &state, key(bento.KeyCtrlY))
+	require.Equal(t, "foo bar ", state.String())
+
+	send(t, &state, bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune("y"), Alt: true})
+	require.Equal(t, "baz ", state.String())
+
+	// the yank is undone at once
+	send(t, &state, key(bento.KeyCtrlZ))
+	require.Equal(t, " ", state.String())
+
+	send(t, &state, key(bento.KeyCtrlU))
+	require.Equal(t, "", state.String())
+	require.Equal(t, " ", state.killRing[len(state.killRing)-1])
+}
+
+func TestState_Paste(t *testing.T) {
+	state := NewState()
+
+	send(t, &state, typed("ab")...)
+
+	handled, _ := state.TryUpdate(bento.PasteMsg("x\r\ny"))
+	require.True(t, handled)
+	require.Equal(t, "abx y", state.String())
+
+	// the paste is undone on its own
+	send(t, &state, key(bento.KeyCtrlZ))
+	require.Equal(t, "ab", state.String())
+}
@@ -96,6 +96,32 @@ func (HideCursor) WriteANSI(w io.Writer) error {
 	return write(w, CSI+"?25l")
 }
 
+type RequestCursorPosition struct{}
+
+func (RequestCursorPosition) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"6n")
+}
+
+type AppendLines int
+
+func (a AppendLines) WriteANSI(w io.Writer) error {
+	for i := 0; i < int(a); i++ {
+		if err := write(w, "\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetCursorShape sets the cursor shape (DECSCUSR).
+// Zero resets the shape to the terminal default.
+type SetCursorShape int
+
+func (s SetCursorShape) WriteANSI(w io.Writer) error {
+	return write(w, CSI+strconv.Itoa(int(s))+" q")
+}
+
 type ClearAll struct{}
 
 func (ClearAll) WriteANSI(w io.Writer) error {
@@ -150,6 +176,72 @@ func (DisableBracketedPaste) WriteANSI(w io.Writer) error {
 	return write(w, CSI+"?2004l")
 }
 
+type EnableMouseCellMotion struct{}
+
+func (EnableMouseCellMotion) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"?1002h")
+}
+
+type DisableMouseCellMotion struct{}
+
+func (DisableMouseCellMotion) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"?1002l")
+}
+
+type EnableMouseAllMotion struct{}
+
+func (EnableMouseAllMotion) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"?1003h")
+}
+
+type DisableMouseAllMotion struct{}
+
+func (DisableMouseAllMotion) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"?1003l")
+}
+
+// EnableMouseSGRMode enables extended mouse coordinates (SGR) encoding.
+// Without it coordinates are limited to 223.
+type EnableMouseSGRMode struct{}
+
+func (EnableMouseSGRMode) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"?1006h")
+}
+
+type DisableMouseSGRMode struct{}
+
+func (DisableMouseSGRMode) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"?1006l")
+}
+
+type EnableReportFocus struct{}
+
+func (EnableReportFocus) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"?1004h")
+}
+
+type DisableReportFocus struct{}
+
+func (DisableReportFocus) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"?1004l")
+}
+
+// PushKeyboardEnhancement enables the kitty keyboard protocol with the given flags.
+type PushKeyboardEnhancement struct {
+	Flags int
+}
+
+func (p PushKeyboardEnhancement) WriteANSI(w io.Writer) error {
+	return writef(w, CSI+">%du", p.Flags)
+}
+
+// PopKeyboardEnhancement restores the keyboard protocol flags to the ones before the last push.
+type PopKeyboardEnhancement struct{}
+
+func (PopKeyboardEnhancement) WriteANSI(w io.Writer) error {
+	return write(w, CSI+"<u")
+}
+
 func write(w io.Writer, a ...any) error {
 	_, err := fmt.Fprint(w, a...)
 
@@ -1,11 +1,61 @@
 package ansi
 
-import "github.com/charmbracelet/x/term"
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/charmbracelet/x/term"
+)
 
 func GetSize(fd uintptr) (width, height int, err error) {
 	return term.GetSize(fd)
 }
 
-func GetCursorPosition() (column, row int, err error) {
-	panic("unimplemented")
+// ParseCursorPosition finds a cursor position report (ESC [ row ; column R)
+// in b and returns the zero-based column and row.
+//
+// The report may be preceded or followed by other input, e.g. keys pressed meanwhile.
+// That input is returned as rest.
+func ParseCursorPosition(b []byte) (column, row int, rest []byte, ok bool) {
+	for end := len(b); ; {
+		start := bytes.LastIndex(b[:end], []byte(CSI))
+		if start == -1 {
+			return 0, 0, nil, false
+		}
+
+		if column, row, n, ok := parseCursorPositionReport(b[start+len(CSI):]); ok {
+			rest = append(rest, b[:start]...)
+			rest = append(rest, b[start+len(CSI)+n:]...)
+
+			return column, row, rest, true
+		}
+
+		end = start
+	}
+}
+
+// parseCursorPositionReport parses the report after the CSI
+// and returns the number of bytes it takes.
+func parseCursorPositionReport(b []byte) (column, row, n int, ok bool) {
+	end := bytes.IndexByte(b, 'R')
+	if end == -1 {
+		return 0, 0, 0, false
+	}
+
+	rowStr, columnStr, found := bytes.Cut(b[:end], []byte(";"))
+	if !found {
+		return 0, 0, 0, false
+	}
+
+	row, err := strconv.Atoi(string(rowStr))
+	if err != nil || row < 1 {
+		return 0, 0, 0, false
+	}
+
+	column, err = strconv.Atoi(string(columnStr))
+	if err != nil || column < 1 {
+		return 0, 0, 0, false
+	}
+
+	return column - 1, row - 1, end + 1, true
 }
@@ -0,0 +1,44 @@
+package ansi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCursorPosition(t *testing.T) {
+	testCases := []struct {
+		name   string
+		reply  string
+		column int
+		row    int
+		rest   string
+		ok     bool
+	}{
+		{name: "valid", reply: "\x1b[12;40R", column: 39, row: 11, ok: true},
+		{name: "origin", reply: "\x1b[1;1R", column: 0, row: 0, ok: true},
+		{name: "after keys", reply: "a\x1b[A\x1b[3;7R", column: 6, row: 2, rest: "a\x1b[A", ok: true},
+		{name: "before keys", reply: "\x1b[3;7R\x1b[A", column: 6, row: 2, rest: "\x1b[A", ok: true},
+		{name: "around keys", reply: "ab\x1b[3;7Rc", column: 6, row: 2, rest: "abc", ok: true},
+		{name: "empty", reply: "", ok: false},
+		{name: "truncated", reply: "\x1b[12;4", ok: false},
+		{name: "truncated introducer", reply: "\x1b", ok: false},
+		{name: "no separator", reply: "\x1b[12R", ok: false},
+		{name: "no escape", reply: "12;40R", ok: false},
+		{name: "garbage row", reply: "\x1b[a;40R", ok: false},
+		{name: "garbage column", reply: "\x1b[12;bR", ok: false},
+		{name: "zero", reply: "\x1b[0;0R", ok: false},
+		{name: "other sequence", reply: "\x1b[1;5A", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			column, row, rest, ok := ParseCursorPosition([]byte(tc.reply))
+
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.column, column)
+			require.Equal(t, tc.row, row)
+			require.Equal(t, tc.rest, string(rest))
+		})
+	}
+}
@@ -46,3 +46,35 @@ func (gs Graphemes) String() string {
 
 	return b.String()
 }
+
+// PrevWordStart returns the index of the start of the word before the index,
+// skipping the whitespace in between.
+func (gs Graphemes) PrevWordStart(index int) int {
+	index = min(max(0, index), len(gs))
+
+	for index > 0 && gs[index-1].IsWhitespace() {
+		index--
+	}
+
+	for index > 0 && !gs[index-1].IsWhitespace() {
+		index--
+	}
+
+	return index
+}
+
+// NextWordEnd returns the index after the end of the word after the index,
+// skipping the whitespace in between.
+func (gs Graphemes) NextWordEnd(index int) int {
+	index = min(max(0, index), len(gs))
+
+	for index < len(gs) && gs[index].IsWhitespace() {
+		index++
+	}
+
+	for index < len(gs) && !gs[index].IsWhitespace() {
+		index++
+	}
+
+	return index
+}
@@ -0,0 +1,23 @@
+package scroll
+
+// Highlight spacing modes, the values of listwidget.HighlightSpacing.
+const (
+	HighlightSpacingWhenSelected = iota
+	HighlightSpacingAlways
+	HighlightSpacingNever
+)
+
+// ShouldAddHighlightSpacing reports whether the space for the highlight symbol
+// is added for the given highlight spacing mode.
+func ShouldAddHighlightSpacing(spacing int, hasSelection bool) bool {
+	switch spacing {
+	case HighlightSpacingWhenSelected:
+		return hasSelection
+	case HighlightSpacingAlways:
+		return true
+	case HighlightSpacingNever:
+		return false
+	default:
+		return false
+	}
+}
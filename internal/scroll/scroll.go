@@ -0,0 +1,152 @@
+// Package scroll calculates which of the items of different heights are visible
+// in a scrolled area, e.g. the list items or the table rows.
+package scroll
+
+// Items are the items to scroll through.
+type Items struct {
+	// Count is the number of items.
+	Count int
+
+	// Height returns the height of the item at the given index.
+	// It is only called for the items around the visible ones.
+	Height func(index int) int
+
+	// ScrollPadding is the number of items to keep visible around the selected one.
+	ScrollPadding int
+}
+
+// Bounds given an offset, calculates which items can fit in a given area.
+// It returns the index of the first visible item and the index after the last visible one.
+func (it Items) Bounds(selected *int, offset, maxHeight int) (int, int) {
+	if it.Count == 0 {
+		return 0, 0
+	}
+
+	offset = min(offset, max(0, it.Count-1))
+
+	// NOTE: visible here implies visible in the given area
+	firstVisibleIndex := offset
+	lastVisibleIndex := offset
+
+	// Current height of all items in the list to render, beginning at the offset
+	var heightFromOffset int
+
+	// Calculate the last visible index and total height of the items
+	// that will fit in the available space
+	for i := offset; i < it.Count; i++ {
+		height := it.Height(i)
+
+		if heightFromOffset+height > maxHeight {
+			break
+		}
+
+		heightFromOffset += height
+
+		lastVisibleIndex++
+	}
+
+	// Get the selected index and apply scroll_padding to it, but still honor the offset if
+	// nothing is selected. This allows for the list to stay at a position after selecting
+	// None.
+	indexToDisplay := offset
+	if selected != nil {
+		indexToDisplay = it.applyScrollPaddingToSelectedIndex(
+			*selected,
+			maxHeight,
+			firstVisibleIndex,
+			lastVisibleIndex,
+		)
+	}
+
+	// Recall that last_visible_index is the index of what we
+	// can render up to in the given space after the offset
+	// If we have an item selected that is out of the viewable area (or
+	// the offset is still set), we still need to show this item.
+	//
+	// The items are walked back from the one to display, so that only
+	// the items that end up visible are requested from the source.
+	if indexToDisplay >= lastVisibleIndex {
+		lastVisibleIndex = indexToDisplay + 1
+
+		heightFromOffset = it.Height(indexToDisplay)
+		firstVisibleIndex = indexToDisplay
+
+		if heightFromOffset > maxHeight {
+			// Not even the item to display fits
+			heightFromOffset = 0
+			firstVisibleIndex = lastVisibleIndex
+		}
+
+		for firstVisibleIndex > offset && firstVisibleIndex <= indexToDisplay {
+			height := it.Height(firstVisibleIndex - 1)
+
+			if heightFromOffset+height > maxHeight {
+				break
+			}
+
+			heightFromOffset += height
+
+			firstVisibleIndex--
+		}
+	}
+
+	// Here we're doing something similar to what we just did above
+	// If the selected item index is not in the viewable area, let's try to show the item
+	for indexToDisplay < firstVisibleIndex {
+		firstVisibleIndex--
+
+		heightFromOffset += it.Height(firstVisibleIndex)
+
+		// Don't show an item if it is beyond our viewable height
+		for heightFromOffset > maxHeight {
+			lastVisibleIndex--
+
+			heightFromOffset = max(0, heightFromOffset-it.Height(lastVisibleIndex))
+		}
+	}
+
+	return firstVisibleIndex, lastVisibleIndex
+}
+
+// applyScrollPaddingToSelectedIndex applies scroll padding to the selected index, reducing the padding value to keep the
+// selected item on screen even with items of inconsistent sizes
+//
+// This function is sensitive to how the bounds checking function handles item height
+func (it Items) applyScrollPaddingToSelectedIndex(selected int, maxHeight, firstVisibleIndex, lastVisibleIndex int) int {
+	lastValidIndex := max(0, it.Count-1)
+
+	selected = min(selected, lastValidIndex)
+
+	// The below loop handles situations where the list item sizes may not be consistent,
+	// where the offset would have excluded some items that we want to include, or could
+	// cause the offset value to be set to an inconsistent value each time we render.
+	// The padding value will be reduced in case any of these issues would occur
+	scrollPadding := it.ScrollPadding
+
+	for scrollPadding > 0 {
+		var heightAroundSelected int
+
+		from := max(0, selected-scrollPadding)
+		to := min(lastValidIndex, selected+scrollPadding)
+
+		for i := from; i <= to; i++ {
+			heightAroundSelected += it.Height(i)
+		}
+
+		if heightAroundSelected <= maxHeight {
+			break
+		}
+
+		scrollPadding--
+	}
+
+	res := selected
+
+	if min(lastValidIndex, selected+scrollPadding) >= lastVisibleIndex {
+		res = selected + scrollPadding
+	} else if max(0, selected-scrollPadding) < firstVisibleIndex {
+		res = max(0, selected-scrollPadding)
+	}
+
+	return min(res, lastValidIndex)
+}
@@ -0,0 +1,46 @@
+package scroll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItems_Bounds(t *testing.T) {
+	ptr := func(i int) *int { return &i }
+
+	testCases := []struct {
+		name          string
+		heights       []int
+		scrollPadding int
+		selected      *int
+		offset        int
+		maxHeight     int
+		first, last   int
+	}{
+		{name: "fits", heights: []int{1, 1, 1}, maxHeight: 5, first: 0, last: 3},
+		{name: "offset", heights: []int{1, 1, 1, 1, 1}, offset: 2, maxHeight: 2, first: 2, last: 4},
+		{name: "selected below", heights: []int{1, 1, 1, 1, 1}, selected: ptr(4), maxHeight: 2, first: 3, last: 5},
+		{name: "selected above", heights: []int{1, 1, 1, 1, 1}, selected: ptr(0), offset: 3, maxHeight: 2, first: 0, last: 2},
+		{name: "scroll padding", heights: []int{1, 1, 1, 1, 1}, scrollPadding: 1, selected: ptr(2), maxHeight: 3, first: 1, last: 4},
+		{name: "padding reduced", heights: []int{2, 2, 2, 2}, scrollPadding: 1, selected: ptr(2), maxHeight: 4, first: 1, last: 3},
+		{name: "different heights", heights: []int{1, 3, 1, 2}, selected: ptr(3), maxHeight: 3, first: 2, last: 4},
+		{name: "selected too tall", heights: []int{1, 5, 1}, selected: ptr(1), maxHeight: 3, first: 1, last: 1},
+		{name: "empty", maxHeight: 3, first: 0, last: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			items := Items{
+				Count:         len(tc.heights),
+				Height:        func(index int) int { return tc.heights[index] },
+				ScrollPadding: tc.scrollPadding,
+			}
+
+			first, last := items.Bounds(tc.selected, tc.offset, tc.maxHeight)
+
+			require.Equal(t, tc.first, first)
+			require.Equal(t, tc.last, last)
+		})
+	}
+}
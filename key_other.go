@@ -7,6 +7,6 @@ import (
 	"io"
 )
 
-func readInputs(ctx context.Context, msgs chan<- Msg, input io.Reader) error {
-	return readAnsiInputs(ctx, msgs, input)
+func readInputs(ctx context.Context, msgs chan<- Msg, input io.Reader, maxPasteSize int) error {
+	return readAnsiInputs(ctx, msgs, input, maxPasteSize)
 }
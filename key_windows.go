@@ -12,12 +12,12 @@ import (
 	"golang.org/x/sys/windows"
 )
 
-func readInputs(ctx context.Context, msgs chan<- Msg, input io.Reader) error {
+func readInputs(ctx context.Context, msgs chan<- Msg, input io.Reader, maxPasteSize int) error {
 	if coninReader, ok := input.(*conInputReader); ok {
 		return readConInputs(ctx, msgs, coninReader.conin)
 	}
 
-	return readAnsiInputs(ctx, msgs, localereader.NewReader(input))
+	return readAnsiInputs(ctx, msgs, localereader.NewReader(input), maxPasteSize)
 }
 
 func readConInputs(ctx context.Context, msgsch chan<- Msg, con windows.Handle) error {
@@ -0,0 +1,419 @@
+package bento
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// KeyboardEnhancement are the progressive enhancement flags of the kitty keyboard protocol.
+//
+// See https://sw.kovidgoyal.net/kitty/keyboard-protocol/#progressive-enhancement
+type KeyboardEnhancement int
+
+const (
+	// KeyboardDisambiguateEscapeCodes reports the keys which are ambiguous in legacy terminals,
+	// e.g. ctrl+i and tab or esc and alt, with escape codes.
+	KeyboardDisambiguateEscapeCodes KeyboardEnhancement = 1 << iota
+
+	// KeyboardReportEventTypes reports repeat and release events.
+	KeyboardReportEventTypes
+
+	// KeyboardReportAlternateKeys reports the shifted key along with the key itself.
+	KeyboardReportAlternateKeys
+
+	// KeyboardReportAllKeysAsEscapeCodes reports all keys with escape codes, including the text ones.
+	KeyboardReportAllKeysAsEscapeCodes
+
+	// KeyboardReportAssociatedText reports the text produced by the key.
+	// It requires [KeyboardReportAllKeysAsEscapeCodes].
+	KeyboardReportAssociatedText
+)
+
+// KeyMod is a set of key modifiers.
+type KeyMod int
+
+const (
+	ModShift KeyMod = 1 << iota
+	ModAlt
+	ModCtrl
+	ModSuper
+	ModHyper
+	ModMeta
+	ModCapsLock
+	ModNumLock
+)
+
+// Contains reports whether all the given modifiers are set.
+func (m KeyMod) Contains(other KeyMod) bool {
+	return m&other == other
+}
+
+// KeyEventType is the kind of a key event.
+type KeyEventType int
+
+const (
+	KeyEventPress KeyEventType = iota
+	KeyEventRepeat
+	KeyEventRelease
+)
+
+// KeyReleaseMsg is sent when a key is released.
+// It is only reported by terminals supporting the kitty keyboard protocol
+// with [KeyboardReportEventTypes] enabled.
+//
+// Releases are a separate message, so that models handling [KeyMsg] don't act twice on each key.
+type KeyReleaseMsg Key
+
+// String returns a string representation of the released key, see [Key.String].
+func (k KeyReleaseMsg) String() string {
+	return Key(k).String()
+}
+
+// keyModNames are the modifiers shown in the key string, in order.
+// The legacy key strings show alt before ctrl and ctrl before shift, e.g. "alt+ctrl+shift+up".
+var keyModNames = []struct {
+	mod  KeyMod
+	name string
+}{
+	{ModAlt, "alt+"},
+	{ModCtrl, "ctrl+"},
+	{ModShift, "shift+"},
+	{ModSuper, "super+"},
+	{ModHyper, "hyper+"},
+	{ModMeta, "meta+"},
+}
+
+// modifiedKeyTypes are the legacy key types of keys with shift and ctrl modifiers,
+// indexed by the shift bit plus twice the ctrl bit.
+var modifiedKeyTypes = map[KeyType][4]KeyType{
+	KeyUp:     {KeyUp, KeyShiftUp, KeyCtrlUp, KeyCtrlShiftUp},
+	KeyDown:   {KeyDown, KeyShiftDown, KeyCtrlDown, KeyCtrlShiftDown},
+	KeyLeft:   {KeyLeft, KeyShiftLeft, KeyCtrlLeft, KeyCtrlShiftLeft},
+	KeyRight:  {KeyRight, KeyShiftRight, KeyCtrlRight, KeyCtrlShiftRight},
+	KeyHome:   {KeyHome, KeyShiftHome, KeyCtrlHome, KeyCtrlShiftHome},
+	KeyEnd:    {KeyEnd, KeyShiftEnd, KeyCtrlEnd, KeyCtrlShiftEnd},
+	KeyPgUp:   {KeyPgUp, KeyPgUp, KeyCtrlPgUp, KeyCtrlPgUp},
+	KeyPgDown: {KeyPgDown, KeyPgDown, KeyCtrlPgDown, KeyCtrlPgDown},
+	KeyTab:    {KeyTab, KeyShiftTab, KeyTab, KeyShiftTab},
+}
+
+// keyBases maps the legacy key types with modifiers to the keys without them.
+var keyBases = func() map[KeyType]KeyType {
+	bases := make(map[KeyType]KeyType)
+
+	for base, types := range modifiedKeyTypes {
+		for _, t := range types {
+			if t != base {
+				bases[t] = base
+			}
+		}
+	}
+
+	return bases
+}()
+
+// modString returns the string representation of a key with the modifiers
+// reported by the kitty keyboard protocol.
+func (k Key) modString() string {
+	mod := k.Mod
+
+	// the text is already shifted
+	if k.Type == KeyRunes || k.Type == KeySpace {
+		mod &^= ModShift
+	}
+
+	var buf strings.Builder
+
+	for _, m := range keyModNames {
+		if mod&m.mod != 0 {
+			buf.WriteString(m.name)
+		}
+	}
+
+	switch base, ok := keyBases[k.Type]; {
+	case ok:
+		buf.WriteString(keyNames[base])
+	case k.Type == KeyRunes, len(k.Runes) > 0 && k.Type >= keyNUL && k.Type <= keyUS:
+		buf.WriteString(string(k.Runes))
+	default:
+		buf.WriteString(strings.TrimPrefix(keyNames[k.Type], "ctrl+"))
+	}
+
+	return buf.String()
+}
+
+// kittyKeyRe matches the key events of the kitty keyboard protocol:
+//
+//	CSI code[:shifted[:base]] [; modifiers[:event] [; text]] u
+//	CSI number [; modifiers[:event]] ~
+//	CSI 1 [; modifiers[:event]] {ABCDFHPQS}
+var kittyKeyRe = regexp.MustCompile(`^\x1b\[(\d*(?::\d*)*)(?:;(\d*(?::\d+)?)(?:;([\d:]*))?)?([u~ABCDFHPQS])`)
+
+// kittyLetterKeys are the keys reported with the CSI 1 {ABCDFHPQS} sequences.
+var kittyLetterKeys = map[byte]KeyType{
+	'A': KeyUp,
+	'B': KeyDown,
+	'C': KeyRight,
+	'D': KeyLeft,
+	'F': KeyEnd,
+	'H': KeyHome,
+	'P': KeyF1,
+	'Q': KeyF2,
+	'S': KeyF4,
+}
+
+// kittyTildeKeys are the keys reported with the CSI number ~ sequences.
+var kittyTildeKeys = map[int]KeyType{
+	2:  KeyInsert,
+	3:  KeyDelete,
+	5:  KeyPgUp,
+	6:  KeyPgDown,
+	7:  KeyHome,
+	8:  KeyEnd,
+	11: KeyF1,
+	12: KeyF2,
+	13: KeyF3,
+	14: KeyF4,
+	15: KeyF5,
+	17: KeyF6,
+	18: KeyF7,
+	19: KeyF8,
+	20: KeyF9,
+	21: KeyF10,
+	23: KeyF11,
+	24: KeyF12,
+}
+
+// kittyFunctionalKeys are the keys reported with the CSI code u sequences
+// that don't produce text. Most of them are in the unicode private use area.
+var kittyFunctionalKeys = map[int]KeyType{
+	9:     KeyTab,
+	13:    KeyEnter,
+	27:    KeyEsc,
+	127:   KeyBackspace,
+	57376: KeyF13,
+	57377: KeyF14,
+	57378: KeyF15,
+	57379: KeyF16,
+	57380: KeyF17,
+	57381: KeyF18,
+	57382: KeyF19,
+	57383: KeyF20,
+	57414: KeyEnter,
+	57417: KeyLeft,
+	57418: KeyRight,
+	57419: KeyUp,
+	57420: KeyDown,
+	57421: KeyPgUp,
+	57422: KeyPgDown,
+	57423: KeyHome,
+	57424: KeyEnd,
+	57425: KeyInsert,
+	57426: KeyDelete,
+}
+
+// kittyKeypadRunes are the text keys of the keypad.
+var kittyKeypadRunes = map[int]rune{
+	57399: '0',
+	57400: '1',
+	57401: '2',
+	57402: '3',
+	57403: '4',
+	57404: '5',
+	57405: '6',
+	57406: '7',
+	57407: '8',
+	57408: '9',
+	57409: '.',
+	57410: '/',
+	57411: '*',
+	57412: '-',
+	57413: '+',
+	57415: '=',
+}
+
+// detectKittyKey detects the key events of the kitty keyboard protocol.
+//
+// The legacy sequences known to detectSequence, e.g. CSI 1;5A, are left to it.
+// Press and repeat events are reported as [KeyMsg] and release events as [KeyReleaseMsg].
+func detectKittyKey(input []byte) (hasKey bool, width int, msg Msg) {
+	match := kittyKeyRe.FindSubmatch(input)
+	if match == nil {
+		return false, 0, nil
+	}
+
+	seq := match[0]
+	final := seq[len(seq)-1]
+
+	if final != 'u' {
+		if _, ok := extSequences[string(seq)]; ok {
+			return false, 0, nil
+		}
+	}
+
+	key, ok := parseKittyKey(final, match[1], match[2], match[3])
+	if !ok {
+		return true, len(seq), unknownCSISequenceMsg(seq)
+	}
+
+	if key.Event == KeyEventRelease {
+		return true, len(seq), KeyReleaseMsg(key)
+	}
+
+	return true, len(seq), KeyMsg(key)
+}
+
+func parseKittyKey(final byte, codes, modifiers, text []byte) (Key, bool) {
+	var key Key
+
+	if len(modifiers) > 0 {
+		mods, event, _ := bytes.Cut(modifiers, []byte(":"))
+
+		if len(mods) > 0 {
+			m, err := strconv.Atoi(string(mods))
+			if err != nil || m < 1 {
+				return Key{}, false
+			}
+
+			key.Mod = KeyMod(m - 1)
+		}
+
+		if len(event) > 0 {
+			e, err := strconv.Atoi(string(event))
+			if err != nil || e < 1 || e > 3 {
+				return Key{}, false
+			}
+
+			key.Event = KeyEventType(e - 1)
+		}
+	}
+
+	key.Alt = key.Mod&ModAlt != 0
+
+	var (
+		base KeyType
+		ok   bool
+	)
+
+	switch final {
+	case 'u':
+		var fields []int
+
+		for _, field := range bytes.Split(codes, []byte(":")) {
+			code, err := strconv.Atoi(string(field))
+			if err != nil {
+				code = 0
+			}
+
+			fields = append(fields, code)
+		}
+
+		code := fields[0]
+		if code == 0 {
+			return Key{}, false
+		}
+
+		if base, ok = kittyFunctionalKeys[code]; !ok {
+			var shifted int
+			if len(fields) > 1 {
+				shifted = fields[1]
+			}
+
+			return kittyTextKey(key, code, shifted, text)
+		}
+	case '~':
+		number, err := strconv.Atoi(string(codes))
+		if err != nil {
+			return Key{}, false
+		}
+
+		base, ok = kittyTildeKeys[number]
+	default:
+		base, ok = kittyLetterKeys[final]
+	}
+
+	if !ok {
+		return Key{}, false
+	}
+
+	key.Type = base
+
+	if types, ok := modifiedKeyTypes[base]; ok {
+		var index int
+
+		if key.Mod&ModShift != 0 {
+			index++
+		}
+
+		if key.Mod&ModCtrl != 0 {
+			index += 2
+		}
+
+		key.Type = types[index]
+	}
+
+	return key, true
+}
+
+// kittyTextKey returns the key of the given unicode code point, which usually produces text.
+// ctrl+letter keys have the legacy control key types, e.g. [KeyCtrlZ], to keep working with the
+// models not aware of the modifiers.
+func kittyTextKey(key Key, code, shifted int, text []byte) (Key, bool) {
+	r, isKeypad := kittyKeypadRunes[code]
+	if !isKeypad {
+		if code > unicode.MaxRune || (code >= 57344 && code <= 63743) {
+			// other private use area keys, e.g. media and modifier keys
+			return Key{}, false
+		}
+
+		r = rune(code)
+	}
+
+	ctrl := key.Mod&ModCtrl != 0
+	lower := unicode.ToLower(r)
+
+	switch {
+	case ctrl && lower >= 'a' && lower <= 'z':
+		key.Type = KeyCtrlA + KeyType(lower-'a')
+		key.Runes = []rune{lower}
+
+		return key, true
+	case r == ' ':
+		key.Type = KeySpace
+		key.Runes = spaceRunes
+
+		return key, true
+	}
+
+	key.Type = KeyRunes
+	key.Runes = []rune{r}
+
+	shift := key.Mod&ModShift != 0
+
+	switch {
+	case len(text) > 0:
+		var runes []rune
+
+		for _, field := range bytes.Split(text, []byte(":")) {
+			code, err := strconv.Atoi(string(field))
+			if err != nil || code <= 0 || code > unicode.MaxRune {
+				continue
+			}
+
+			runes = append(runes, rune(code))
+		}
+
+		if len(runes) > 0 {
+			key.Runes = runes
+		}
+	case shift && shifted > 0:
+		key.Runes = []rune{rune(shifted)}
+	case shift != (key.Mod&ModCapsLock != 0):
+		key.Runes = []rune{unicode.ToUpper(r)}
+	}
+
+	return key, true
+}
@@ -0,0 +1,194 @@
+package bento_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/stretchr/testify/require"
+)
+
+type keysModel struct {
+	keys []string
+}
+
+func (m keysModel) Init() bento.Cmd {
+	return nil
+}
+
+func (m keysModel) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
+	switch msg := msg.(type) {
+	case bento.KeyMsg:
+		if msg.Type == bento.KeyEsc {
+			return m, bento.Quit
+		}
+
+		m.keys = append(m.keys, msg.String())
+	case bento.KeyReleaseMsg:
+		m.keys = append(m.keys, "release "+msg.String())
+	case bento.FocusMsg:
+		m.keys = append(m.keys, "focus")
+	case bento.BlurMsg:
+		m.keys = append(m.keys, "blur")
+	}
+
+	return m, nil
+}
+
+func (keysModel) Render(bento.Rect, *bento.Buffer) {}
+
+func readKeys(t *testing.T, input string) ([]string, string) {
+	t.Helper()
+
+	var output bytes.Buffer
+
+	model, err := bento.NewApp(keysModel{}).
+		WithInput(strings.NewReader(input + "\x1b[27u")).
+		WithOutput(&output).
+		WithKeyboardEnhancement(bento.KeyboardDisambiguateEscapeCodes | bento.KeyboardReportEventTypes).
+		Run()
+	require.NoError(t, err)
+
+	return model.(keysModel).keys, output.String()
+}
+
+func TestKeyboardEnhancement(t *testing.T) {
+	keys, output := readKeys(t, strings.Join([]string{
+		"\x1b[105;5u",     // ctrl+i
+		"\t",              // tab, legacy
+		"\x1b[122;6u",     // ctrl+shift+z
+		"\x1b[97;2u",      // shift+a
+		"\x1b[97:65;4u",   // alt+shift+a with the shifted key
+		"\x1b[97;9u",      // super+a
+		"\x1b[1;5:2A",     // ctrl+up repeat
+		"\x1b[3;3~",       // alt+delete
+		"\x1b[97;1:3u",    // a release
+		"\x1b[57399;129u", // keypad 0 with num lock
+		"\x1b[1;5A",       // ctrl+up, legacy
+	}, ""))
+
+	require.Equal(t, []string{
+		"ctrl+i",
+		"tab",
+		"ctrl+shift+z",
+		"A",
+		"alt+A",
+		"super+a",
+		"ctrl+up",
+		"alt+delete",
+		"release a",
+		"0",
+		"ctrl+up",
+	}, keys)
+
+	require.Contains(t, output, "\x1b[>3u")
+	require.Contains(t, output, "\x1b[<u")
+}
+
+func TestReportFocus(t *testing.T) {
+	keys, _ := readKeys(t, strings.Join([]string{
+		"\x1b[I",   // focus
+		"\x1b[O",   // blur
+		"\x1b[OA",  // shift+up, DECCKM
+		"\x1b[Oa",  // alt+ctrl+up, urxvt
+		"\x1b[O",   // blur
+		"\x1b[A",   // up
+		"\x1b[Ix",  // focus followed by a key
+		"\x1b[O\t", // blur followed by a key
+	}, ""))
+
+	require.Equal(t, []string{
+		"focus",
+		"blur",
+		"shift+up",
+		"alt+ctrl+up",
+		"blur",
+		"up",
+		"focus",
+		"x",
+		"blur",
+		"tab",
+	}, keys)
+}
+
+func TestApp_WithReportFocus(t *testing.T) {
+	output := runOutput(t, func(model bento.Model) bento.App {
+		return bento.NewApp(model).WithReportFocus()
+	}, bento.Quit)
+
+	requireInOrder(t, output, "\x1b[?1004h", "\x1b[?1004l")
+
+	// enabled at runtime and disabled on exit
+	output = runOutput(t, bento.NewApp, bento.Sequence(bento.EnableReportFocus, bento.Quit))
+
+	requireInOrder(t, output, "\x1b[?1004h", "\x1b[?1004l")
+
+	output = runOutput(t, bento.NewApp, bento.Quit)
+
+	require.NotContains(t, output, "\x1b[?1004h")
+	require.NotContains(t, output, "\x1b[?1004l")
+}
+
+func TestTerminal_ReportFocus(t *testing.T) {
+	backend := bentotest.NewBackend(10, 2)
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportFullscreen{})
+	require.NoError(t, err)
+
+	require.NoError(t, terminal.HandleMsg(bento.EnableReportFocus()))
+	require.True(t, backend.ReportFocus())
+	require.True(t, terminal.ReportFocusEnabled())
+
+	require.NoError(t, terminal.HandleMsg(bento.DisableReportFocus()))
+	require.False(t, backend.ReportFocus())
+	require.False(t, terminal.ReportFocusEnabled())
+}
+
+func TestKeyboardEnhancement_LegacyTypes(t *testing.T) {
+	var msgs []bento.KeyMsg
+
+	model := bento.NewApp(recordKeyMsgs(func(msg bento.KeyMsg) {
+		msgs = append(msgs, msg)
+	}))
+
+	_, err := model.
+		WithInput(strings.NewReader("\x1b[122;5u\x1b[67;6u\x1b[1;2:1H\x1b[27u")).
+		WithOutput(&bytes.Buffer{}).
+		WithKeyboardEnhancement(bento.KeyboardDisambiguateEscapeCodes).
+		Run()
+	require.NoError(t, err)
+
+	require.Len(t, msgs, 3)
+
+	require.Equal(t, bento.KeyCtrlZ, msgs[0].Type)
+	require.Equal(t, bento.ModCtrl, msgs[0].Mod)
+
+	require.Equal(t, bento.KeyCtrlC, msgs[1].Type)
+	require.True(t, msgs[1].Mod.Contains(bento.ModCtrl|bento.ModShift))
+	require.Equal(t, "ctrl+shift+c", msgs[1].String())
+
+	require.Equal(t, bento.KeyShiftHome, msgs[2].Type)
+	require.Equal(t, bento.KeyEventPress, msgs[2].Event)
+}
+
+type recordKeyMsgs func(msg bento.KeyMsg)
+
+func (r recordKeyMsgs) Init() bento.Cmd {
+	return nil
+}
+
+func (r recordKeyMsgs) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
+	if msg, ok := msg.(bento.KeyMsg); ok {
+		if msg.Type == bento.KeyEsc {
+			return r, bento.Quit
+		}
+
+		r(msg)
+	}
+
+	return r, nil
+}
+
+func (recordKeyMsgs) Render(bento.Rect, *bento.Buffer) {}
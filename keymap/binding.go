@@ -0,0 +1,81 @@
+package keymap
+
+import (
+	"slices"
+
+	"github.com/metafates/bento"
+)
+
+// Binding binds the keys to an action, e.g. "j" and "down" to moving down.
+//
+// The keys are matched against [bento.Key.String], e.g. "ctrl+c", "alt+enter" or "G".
+type Binding struct {
+	keys     []string
+	help     Help
+	disabled bool
+}
+
+// Help is the help text of a binding.
+type Help struct {
+	// Key is how the keys are shown, e.g. "↑/k".
+	Key string
+
+	// Desc is what the binding does, e.g. "up".
+	Desc string
+}
+
+// NewBinding returns a new enabled binding of the given keys.
+func NewBinding(keys ...string) Binding {
+	return Binding{
+		keys:     keys,
+		help:     Help{},
+		disabled: false,
+	}
+}
+
+// WithKeys replaces the keys of the binding.
+func (b Binding) WithKeys(keys ...string) Binding {
+	b.keys = keys
+	return b
+}
+
+func (b Binding) WithHelp(key, desc string) Binding {
+	b.help = Help{Key: key, Desc: desc}
+	return b
+}
+
+// WithEnabled enables or disables the binding.
+// Disabled bindings match no keys and are not shown in the help.
+func (b Binding) WithEnabled(enabled bool) Binding {
+	b.disabled = !enabled
+	return b
+}
+
+func (b Binding) Keys() []string {
+	return b.keys
+}
+
+func (b Binding) Help() Help {
+	return b.help
+}
+
+// Enabled reports whether the binding is enabled and has keys.
+func (b Binding) Enabled() bool {
+	return !b.disabled && len(b.keys) > 0
+}
+
+// Matches reports whether the key is one of the binding keys.
+func (b Binding) Matches(key bento.Key) bool {
+	return b.Enabled() && slices.Contains(b.keys, key.String())
+}
+
+// Matches reports whether the key matches any of the bindings.
+func Matches(key bento.Key, bindings ...Binding) bool {
+	for _, binding := range bindings {
+		if binding.Matches(key) {
+			return true
+		}
+	}
+
+	return false
+}
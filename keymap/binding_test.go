@@ -0,0 +1,29 @@
+package keymap
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinding_Matches(t *testing.T) {
+	down := NewBinding("j", "down")
+
+	require.True(t, down.Matches(bento.Key{Type: bento.KeyRunes, Runes: []rune("j")}))
+	require.True(t, down.Matches(bento.Key{Type: bento.KeyDown}))
+	require.False(t, down.Matches(bento.Key{Type: bento.KeyRunes, Runes: []rune("j"), Alt: true}))
+
+	// pasted text never matches
+	require.False(t, down.Matches(bento.Key{Type: bento.KeyRunes, Runes: []rune("j"), Paste: true}))
+
+	disabled := down.WithEnabled(false)
+	require.False(t, disabled.Enabled())
+	require.False(t, disabled.Matches(bento.Key{Type: bento.KeyDown}))
+
+	quit := NewBinding("ctrl+c")
+	require.True(t, Matches(bento.Key{Type: bento.KeyCtrlC}, down, quit))
+	require.False(t, Matches(bento.Key{Type: bento.KeyCtrlC}, down))
+
+	require.False(t, NewBinding().Enabled())
+}
@@ -0,0 +1,42 @@
+// Package keymap provides declarative key bindings.
+//
+// Widget states match the keys against their key maps instead of hard-coded keys,
+// so the keys can be remapped and the help can be rendered with the helpwidget.
+package keymap
+
+// KeyMap is a set of bindings shown in the help.
+type KeyMap interface {
+	// ShortHelp returns the bindings shown in the single line help.
+	ShortHelp() []Binding
+
+	// FullHelp returns the groups of bindings shown in the full help, one column per group.
+	FullHelp() [][]Binding
+}
+
+// Join returns a key map of the bindings of all the given key maps,
+// e.g. of the focused widget and the app.
+func Join(keyMaps ...KeyMap) KeyMap {
+	return joined(keyMaps)
+}
+
+type joined []KeyMap
+
+func (j joined) ShortHelp() []Binding {
+	var bindings []Binding
+
+	for _, keyMap := range j {
+		bindings = append(bindings, keyMap.ShortHelp()...)
+	}
+
+	return bindings
+}
+
+func (j joined) FullHelp() [][]Binding {
+	var groups [][]Binding
+
+	for _, keyMap := range j {
+		groups = append(groups, keyMap.FullHelp()...)
+	}
+
+	return groups
+}
@@ -57,6 +57,9 @@ type Layout struct {
 	Padding     Padding
 	Flex        Flex
 	Spacing     Spacing
+
+	// Relations are additional relations between segment sizes.
+	Relations []Relation
 }
 
 func NewLayout(constraints ...Constraint) Layout {
@@ -102,8 +105,16 @@ func (l Layout) WithConstraints(constraints ...Constraint) Layout {
 	return l
 }
 
+// WithRelations adds relations between segment sizes, see [Relation].
+func (l Layout) WithRelations(relations ...Relation) Layout {
+	l.Relations = append(l.Relations, relations...)
+	return l
+}
+
+// SplitWithSpacers splits the area into segments and the spacers between them.
+// It panics if the constraints can not be solved, see [Layout.TrySplitWithSpacers].
 func (l Layout) SplitWithSpacers(area Rect) (segments, spacers Splitted) {
-	segments, spacers, err := l.split(area)
+	segments, spacers, err := l.TrySplitWithSpacers(area)
 	if err != nil {
 		panic(err)
 	}
@@ -111,17 +122,90 @@ func (l Layout) SplitWithSpacers(area Rect) (segments, spacers Splitted) {
 	return segments, spacers
 }
 
+// Split splits the area into segments.
+// It panics if the constraints can not be solved, see [Layout.TrySplit].
 func (l Layout) Split(area Rect) Splitted {
 	segments, _ := l.SplitWithSpacers(area)
 
 	return segments
 }
 
+// TrySplitWithSpacers is like [Layout.SplitWithSpacers] but returns a [*LayoutError] instead of panicking.
+func (l Layout) TrySplitWithSpacers(area Rect) (segments, spacers Splitted, err error) {
+	segments, spacers, err = l.split(area)
+	if err != nil {
+		return nil, nil, l.newError(area, err)
+	}
+
+	writeLayoutDebug(l, area, segments, spacers)
+
+	return segments, spacers, nil
+}
+
+// TrySplit is like [Layout.Split] but returns a [*LayoutError] instead of panicking.
+func (l Layout) TrySplit(area Rect) (Splitted, error) {
+	segments, _, err := l.TrySplitWithSpacers(area)
+
+	return segments, err
+}
+
+func (l Layout) newError(area Rect, err error) *LayoutError {
+	return &LayoutError{
+		Direction:   l.Direction,
+		Constraints: l.Constraints,
+		Area:        area,
+		Err:         err,
+	}
+}
+
 func (l Layout) split(area Rect) (segments []Rect, spacers []Rect, err error) {
+	if !isLayoutCacheEnabled() {
+		return l.solve(area)
+	}
+
+	key := newLayoutCacheKey(l, area)
+
+	if segments, spacers, ok := getCachedSplit(key); ok {
+		return segments, spacers, nil
+	}
+
+	segments, spacers, err = l.solve(area)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	putCachedSplit(key, segments, spacers)
+
+	return segments, spacers, nil
+}
+
+func (l Layout) solve(area Rect) (segments []Rect, spacers []Rect, err error) {
 	solver := casso.NewSolver()
 
 	innerArea := area.Inner(l.Padding)
 
+	elements, err := l.configure(&solver, innerArea)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes := fetchChanges(&solver)
+
+	segments = changesToRects(changes, elements.segments, innerArea, l.Direction)
+	spacers = changesToRects(changes, elements.spacers, innerArea, l.Direction)
+
+	return segments, spacers, nil
+}
+
+// _LayoutElements are the solver variables of a configured layout.
+type _LayoutElements struct {
+	area     _Element
+	segments []_Element
+	spacers  []_Element
+}
+
+// configure adds the variables and constraints of the layout splitting the inner area to the solver.
+func (l Layout) configure(solver *casso.Solver, innerArea Rect) (_LayoutElements, error) {
 	var areaStart, areaEnd float64
 
 	switch l.Direction {
@@ -157,28 +241,32 @@ func (l Layout) split(area Rect) (segments []Rect, spacers []Rect, err error) {
 		End:   variables[len(variables)-1],
 	}
 
-	if err := configureArea(&solver, areaSize, areaStart, areaEnd); err != nil {
-		return nil, nil, fmt.Errorf("configure area: %w", err)
+	if err := configureArea(solver, areaSize, areaStart, areaEnd); err != nil {
+		return _LayoutElements{}, fmt.Errorf("configure area: %w", err)
 	}
 
-	if err := configureVariableInAreaConstraints(&solver, variables, areaSize); err != nil {
-		return nil, nil, fmt.Errorf("configure variable in area constraints: %w", err)
+	if err := configureVariableInAreaConstraints(solver, variables, areaSize); err != nil {
+		return _LayoutElements{}, fmt.Errorf("configure variable in area constraints: %w", err)
 	}
 
-	if err := configureVariableConstraints(&solver, variables); err != nil {
-		return nil, nil, fmt.Errorf("configure variable constraints: %w", err)
+	if err := configureVariableConstraints(solver, variables); err != nil {
+		return _LayoutElements{}, fmt.Errorf("configure variable constraints: %w", err)
 	}
 
-	if err := configureFlexConstraints(&solver, areaSize, spacerElements, l.Flex, spacing); err != nil {
-		return nil, nil, fmt.Errorf("configure flex constraints: %w", err)
+	if err := configureFlexConstraints(solver, areaSize, spacerElements, l.Flex, spacing); err != nil {
+		return _LayoutElements{}, fmt.Errorf("configure flex constraints: %w", err)
 	}
 
-	if err := configureConstraints(&solver, areaSize, segmentElements, l.Constraints, l.Flex); err != nil {
-		return nil, nil, fmt.Errorf("configure constraints: %w", err)
+	if err := configureConstraints(solver, areaSize, segmentElements, l.Constraints, l.Flex); err != nil {
+		return _LayoutElements{}, fmt.Errorf("configure constraints: %w", err)
 	}
 
-	if err := configureFillConstraints(&solver, segmentElements, l.Constraints, l.Flex); err != nil {
-		return nil, nil, fmt.Errorf("configure fill constraints: %w", err)
+	if err := configureFillConstraints(solver, segmentElements, l.Constraints, l.Flex); err != nil {
+		return _LayoutElements{}, fmt.Errorf("configure fill constraints: %w", err)
+	}
+
+	if err := configureRelations(solver, layoutSizes(areaSize, segmentElements), l.Relations); err != nil {
+		return _LayoutElements{}, fmt.Errorf("configure relations: %w", err)
 	}
 
 	if l.Flex != FlexLegacy {
@@ -187,11 +275,20 @@ func (l Layout) split(area Rect) (segments []Rect, spacers []Rect, err error) {
 			right := segmentElements[i+1]
 
 			if err := solver.AddConstraint(left.hasSize(right.size(), _allSegmentGrow)); err != nil {
-				return nil, nil, fmt.Errorf("add has size constraint: %w", err)
+				return _LayoutElements{}, fmt.Errorf("add has size constraint: %w", err)
 			}
 		}
 	}
 
+	return _LayoutElements{
+		area:     areaSize,
+		segments: segmentElements,
+		spacers:  spacerElements,
+	}, nil
+}
+
+// fetchChanges returns the solved values of the variables.
+func fetchChanges(solver *casso.Solver) map[casso.Variable]float64 {
 	fetched := solver.FetchChanges()
 
 	changes := make(map[casso.Variable]float64, len(fetched))
@@ -199,10 +296,7 @@ func (l Layout) split(area Rect) (segments []Rect, spacers []Rect, err error) {
 		changes[c.Variable] = c.Constant
 	}
 
-	segments = changesToRects(changes, segmentElements, innerArea, l.Direction)
-	spacers = changesToRects(changes, spacerElements, innerArea, l.Direction)
-
-	return segments, spacers, nil
+	return changes
 }
 
 func changesToRects(
@@ -0,0 +1,209 @@
+package bento
+
+import (
+	"container/list"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// layoutCache is the global cache of [Layout] split results.
+// It is nil unless enabled with [EnableLayoutCache].
+var (
+	layoutCacheMu sync.Mutex
+	layoutCache   *lruCache
+)
+
+// CacheStats are the usage statistics of a cache.
+type CacheStats struct {
+	Hits, Misses uint64
+
+	// Len is the current number of entries and Capacity is the maximum one.
+	Len, Capacity int
+}
+
+// HitRate returns the ratio of hits to all lookups, from 0 to 1.
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(total)
+}
+
+// EnableLayoutCache enables caching of [Layout.Split] results,
+// so that splitting the same area with the same layout again skips the constraints solving.
+//
+// At most capacity results are kept, the least recently used ones are evicted first.
+// Enabling the cache again resets it together with its stats.
+// The cache is safe for concurrent use.
+func EnableLayoutCache(capacity int) {
+	layoutCacheMu.Lock()
+	defer layoutCacheMu.Unlock()
+
+	if capacity <= 0 {
+		layoutCache = nil
+		return
+	}
+
+	layoutCache = newLRUCache(capacity)
+}
+
+// DisableLayoutCache disables and drops the layout cache.
+func DisableLayoutCache() {
+	EnableLayoutCache(0)
+}
+
+// LayoutCacheStats returns the layout cache stats.
+// Zero stats are returned if the cache is disabled.
+func LayoutCacheStats() CacheStats {
+	layoutCacheMu.Lock()
+	defer layoutCacheMu.Unlock()
+
+	if layoutCache == nil {
+		return CacheStats{}
+	}
+
+	return layoutCache.stats()
+}
+
+func isLayoutCacheEnabled() bool {
+	layoutCacheMu.Lock()
+	defer layoutCacheMu.Unlock()
+
+	return layoutCache != nil
+}
+
+type layoutCacheKey struct {
+	direction   Direction
+	constraints string
+	relations   string
+	padding     Padding
+	flex        Flex
+	spacing     Spacing
+	area        Rect
+}
+
+func newLayoutCacheKey(layout Layout, area Rect) layoutCacheKey {
+	var constraints strings.Builder
+
+	for _, c := range layout.Constraints {
+		constraints.WriteString(c.String())
+		constraints.WriteByte(';')
+	}
+
+	var relations strings.Builder
+
+	for _, r := range layout.Relations {
+		relations.WriteString(r.String())
+		relations.WriteByte(';')
+	}
+
+	return layoutCacheKey{
+		direction:   layout.Direction,
+		constraints: constraints.String(),
+		relations:   relations.String(),
+		padding:     layout.Padding,
+		flex:        layout.Flex,
+		spacing:     layout.Spacing,
+		area:        area,
+	}
+}
+
+type layoutCacheValue struct {
+	segments, spacers []Rect
+}
+
+func getCachedSplit(key layoutCacheKey) (segments, spacers []Rect, ok bool) {
+	layoutCacheMu.Lock()
+	defer layoutCacheMu.Unlock()
+
+	if layoutCache == nil {
+		return nil, nil, false
+	}
+
+	value, ok := layoutCache.get(key)
+	if !ok {
+		return nil, nil, false
+	}
+
+	// copy, so that the callers can't modify the cached value
+	return slices.Clone(value.segments), slices.Clone(value.spacers), true
+}
+
+func putCachedSplit(key layoutCacheKey, segments, spacers []Rect) {
+	layoutCacheMu.Lock()
+	defer layoutCacheMu.Unlock()
+
+	if layoutCache == nil {
+		return
+	}
+
+	layoutCache.put(key, layoutCacheValue{
+		segments: slices.Clone(segments),
+		spacers:  slices.Clone(spacers),
+	})
+}
+
+// lruCache is a least recently used cache. It is not safe for concurrent use.
+type lruCache struct {
+	capacity int
+	entries  map[layoutCacheKey]*list.Element
+	order    *list.List
+
+	hits, misses uint64
+}
+
+type lruEntry struct {
+	key   layoutCacheKey
+	value layoutCacheValue
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		entries:  make(map[layoutCacheKey]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (c *lruCache) get(key layoutCacheKey) (layoutCacheValue, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return layoutCacheValue{}, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(element)
+
+	return element.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) put(key layoutCacheKey, value layoutCacheValue) {
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruEntry).value = value
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (c *lruCache) stats() CacheStats {
+	return CacheStats{
+		Hits:     c.hits,
+		Misses:   c.misses,
+		Len:      c.order.Len(),
+		Capacity: c.capacity,
+	}
+}
@@ -0,0 +1,46 @@
+package bento_test
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayoutCache(t *testing.T) {
+	bento.EnableLayoutCache(2)
+	defer bento.DisableLayoutCache()
+
+	layout := bento.NewLayout(bento.ConstraintLen(2), bento.ConstraintFill(1)).Horizontal()
+	area := bento.Rect{Width: 10, Height: 1}
+
+	want := bento.Splitted{
+		{X: 0, Y: 0, Width: 2, Height: 1},
+		{X: 2, Y: 0, Width: 8, Height: 1},
+	}
+
+	require.Equal(t, want, layout.Split(area))
+
+	// modifying the result must not affect the cached one
+	layout.Split(area)[0].Width = 100
+
+	require.Equal(t, want, layout.Split(area))
+	require.Equal(t, bento.CacheStats{Hits: 2, Misses: 1, Len: 1, Capacity: 2}, bento.LayoutCacheStats())
+
+	// any difference in the layout is a miss
+	layout.WithSpacing(bento.SpacingSpace(1)).Split(area)
+	layout.WithFlex(bento.FlexCenter).Split(area)
+	layout.WithConstraints(bento.ConstraintLen(1)).Split(area)
+
+	// the least recently used one is evicted
+	layout.Split(area)
+
+	stats := bento.LayoutCacheStats()
+	require.Equal(t, bento.CacheStats{Hits: 2, Misses: 5, Len: 2, Capacity: 2}, stats)
+	require.InDelta(t, 2.0/7.0, stats.HitRate(), 1e-9)
+
+	bento.DisableLayoutCache()
+
+	require.Equal(t, want, layout.Split(area))
+	require.Equal(t, bento.CacheStats{}, bento.LayoutCacheStats())
+}
@@ -0,0 +1,321 @@
+package bento
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/metafates/bento/internal/casso"
+)
+
+var (
+	layoutDebugMu     sync.Mutex
+	layoutDebugOutput io.Writer
+)
+
+// SetLayoutDebugOutput enables the layout debug mode.
+// The [LayoutDebug] report of every split is written to the output.
+// Pass nil to disable it.
+//
+// Since the app owns the terminal, the output is usually a file.
+func SetLayoutDebugOutput(output io.Writer) {
+	layoutDebugMu.Lock()
+	defer layoutDebugMu.Unlock()
+
+	layoutDebugOutput = output
+}
+
+func writeLayoutDebug(l Layout, area Rect, segments, spacers []Rect) {
+	layoutDebugMu.Lock()
+	defer layoutDebugMu.Unlock()
+
+	if layoutDebugOutput == nil {
+		return
+	}
+
+	_, _ = io.WriteString(layoutDebugOutput, newLayoutDebug(l, area, segments, spacers).String())
+}
+
+// LayoutDebug describes how the layout constraints were solved.
+type LayoutDebug struct {
+	Direction Direction
+	Flex      Flex
+
+	// Area is the split area without padding.
+	Area Rect
+
+	Segments []SegmentDebug
+	Spacers  []Rect
+}
+
+// SegmentDebug describes the solved segment of the layout.
+type SegmentDebug struct {
+	Constraint Constraint
+	Rect       Rect
+
+	// Size is the solved size along the layout direction.
+	Size int
+
+	// Requested is the size requested by the constraint for the area,
+	// e.g. the bound for Min and Max or the share of the area for Percentage.
+	// It is zero for Fill, which takes the remaining space.
+	Requested int
+
+	// Strength describes the solver strength of the constraint.
+	Strength string
+
+	// Satisfied reports whether the solved size satisfies the constraint.
+	Satisfied bool
+
+	// OverriddenBy lists the constraints of the same or higher strength that decided the size
+	// if this one was not satisfied, i.e. the competing ones that are tight at the solved sizes.
+	OverriddenBy []string
+}
+
+// Debug splits the area and reports how each segment was solved.
+// Unlike the other splits, it does not write the report to the debug output.
+func (l Layout) Debug(area Rect) (LayoutDebug, error) {
+	segments, spacers, err := l.split(area)
+	if err != nil {
+		return LayoutDebug{}, l.newError(area, err)
+	}
+
+	return newLayoutDebug(l, area, segments, spacers), nil
+}
+
+func (d LayoutDebug) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "layout %s %s, area %+v:\n", d.Direction, d.Flex, d.Area)
+
+	for i, s := range d.Segments {
+		fmt.Fprintf(&b, "  %d %s: size %d, requested %d, %s", i, s.Constraint, s.Size, s.Requested, s.Strength)
+
+		if !s.Satisfied {
+			fmt.Fprintf(&b, ", not satisfied, overridden by: %s", strings.Join(s.OverriddenBy, "; "))
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+type constraintStrength struct {
+	name  string
+	value casso.Strength
+}
+
+func (s constraintStrength) String() string {
+	return s.name
+}
+
+func strengthOf(constraint Constraint) constraintStrength {
+	switch constraint.(type) {
+	case ConstraintMin:
+		return constraintStrength{"min size >= (strong*100)", _minSizeGTE}
+	case ConstraintMax:
+		return constraintStrength{"max size <= (strong*100)", _maxSizeLTE}
+	case ConstraintLen:
+		return constraintStrength{"length == (strong*10)", _lengthSizeEq}
+	case ConstraintPercentage:
+		return constraintStrength{"percentage == (strong)", _percentageSizeEq}
+	case ConstraintRatio:
+		return constraintStrength{"ratio == (strong/10)", _ratioSizeEq}
+	case ConstraintFill:
+		return constraintStrength{"fill grow (medium)", _fillGrow}
+	default:
+		return constraintStrength{"unknown", 0}
+	}
+}
+
+func newLayoutDebug(l Layout, area Rect, segments, spacers []Rect) LayoutDebug {
+	inner := area.Inner(l.Padding)
+
+	available := inner.Height
+	if l.Direction == DirectionHorizontal {
+		available = inner.Width
+	}
+
+	sizeOf := func(r Rect) int {
+		if l.Direction == DirectionHorizontal {
+			return r.Width
+		}
+
+		return r.Height
+	}
+
+	debug := LayoutDebug{
+		Direction: l.Direction,
+		Flex:      l.Flex,
+		Area:      inner,
+		Segments:  make([]SegmentDebug, 0, len(segments)),
+		Spacers:   spacers,
+	}
+
+	solved := _SolvedLayout{
+		layout:    l,
+		available: available,
+	}
+
+	for _, s := range segments {
+		solved.segments = append(solved.segments, sizeOf(s))
+	}
+
+	for _, s := range spacers {
+		solved.spacers = append(solved.spacers, sizeOf(s))
+	}
+
+	for i := 0; i < min(len(segments), len(l.Constraints)); i++ {
+		constraint := l.Constraints[i]
+		strength := strengthOf(constraint)
+
+		segment := SegmentDebug{
+			Constraint: constraint,
+			Rect:       segments[i],
+			Size:       solved.segments[i],
+			Strength:   strength.String(),
+			Satisfied:  true,
+		}
+
+		switch c := constraint.(type) {
+		case ConstraintMin:
+			segment.Requested = int(c)
+			segment.Satisfied = segment.Size >= int(c)
+		case ConstraintMax:
+			segment.Requested = int(c)
+			segment.Satisfied = segment.Size <= int(c)
+		case ConstraintLen:
+			segment.Requested = int(c)
+			segment.Satisfied = segment.Size == int(c)
+		case ConstraintPercentage, ConstraintRatio:
+			segment.Requested = int(math.Round(solved.requested(constraint)))
+			segment.Satisfied = solved.isTight(i)
+		}
+
+		if !segment.Satisfied {
+			segment.OverriddenBy = solved.decidingConstraints(i, strength)
+		}
+
+		debug.Segments = append(debug.Segments, segment)
+	}
+
+	return debug
+}
+
+// _SolvedLayout is a layout with the solved sizes along its direction.
+type _SolvedLayout struct {
+	layout    Layout
+	available int
+	segments  []int
+	spacers   []int
+}
+
+// requested returns the exact size the Percentage or Ratio constraint requests.
+func (s _SolvedLayout) requested(constraint Constraint) float64 {
+	switch c := constraint.(type) {
+	case ConstraintPercentage:
+		return float64(s.available) * float64(c) / 100
+	case ConstraintRatio:
+		return float64(s.available) * float64(c.Num) / float64(max(1, c.Den))
+	default:
+		return 0
+	}
+}
+
+// isTight reports whether the constraint of the segment holds with equality at the solved size,
+// so that it limits the size.
+func (s _SolvedLayout) isTight(index int) bool {
+	size := s.segments[index]
+
+	switch c := s.layout.Constraints[index].(type) {
+	case ConstraintMin:
+		return size == int(c)
+	case ConstraintMax:
+		return size == int(c)
+	case ConstraintLen:
+		return size == int(c)
+	case ConstraintPercentage, ConstraintRatio:
+		return roughlyEqual(size, s.requested(c))
+	default:
+		return false
+	}
+}
+
+// decidingConstraints returns the constraints of the same or higher strength than the one of the segment
+// that are tight at the solved sizes.
+func (s _SolvedLayout) decidingConstraints(index int, strength constraintStrength) []string {
+	var deciding []string
+
+	total := 0
+	for _, size := range append(append([]int{}, s.segments...), s.spacers...) {
+		total += size
+	}
+
+	if total >= s.available {
+		deciding = append(deciding, "area bounds (required)")
+	}
+
+	if spacing, ok := s.layout.Spacing.(SpacingSpace); ok && spacing != 0 && _spacerSizeEq > strength.value {
+		if s.isSpacedBy(int(spacing)) {
+			deciding = append(deciding, fmt.Sprintf("spacing %d (required/10)", spacing))
+		}
+	}
+
+	for i, c := range s.layout.Constraints {
+		if i == index || i >= len(s.segments) {
+			continue
+		}
+
+		if cs := strengthOf(c); cs.value >= strength.value && s.isTight(i) {
+			deciding = append(deciding, fmt.Sprintf("segment %d %s: %s", i, c, cs))
+		}
+	}
+
+	for _, r := range s.layout.Relations {
+		if r.involves(index) && casso.Strength(r.strength) >= strength.value && s.isTightRelation(r) {
+			deciding = append(deciding, "relation "+r.String())
+		}
+	}
+
+	return deciding
+}
+
+// isSpacedBy reports whether the spacers between the segments have the given size.
+func (s _SolvedLayout) isSpacedBy(spacing int) bool {
+	if len(s.spacers) < 2 {
+		return false
+	}
+
+	for _, size := range s.spacers[1 : len(s.spacers)-1] {
+		if size != spacing {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isTightRelation reports whether both sides of the relation are equal at the solved sizes.
+func (s _SolvedLayout) isTightRelation(r Relation) bool {
+	size := func(t sizeTerm) float64 {
+		switch {
+		case t.kind == sizeArea:
+			return float64(s.available)
+		case t.kind == sizeSegment && t.index >= 0 && t.index < len(s.segments):
+			return float64(s.segments[t.index])
+		default:
+			return 0
+		}
+	}
+
+	// the solved sizes are rounded
+	return math.Abs(r.lhs.eval(size)-r.rhs.eval(size)) < 1
+}
+
+// roughlyEqual reports whether the size is the exact size rounded either way.
+func roughlyEqual(size int, exact float64) bool {
+	return size == int(math.Floor(exact)) || size == int(math.Ceil(exact))
+}
@@ -0,0 +1,76 @@
+package bento_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayout_Debug(t *testing.T) {
+	layout := bento.NewLayout(
+		bento.ConstraintLen(3),
+		bento.ConstraintPercentage(50),
+		bento.ConstraintMin(4),
+	).Horizontal().WithSpacing(bento.SpacingSpace(1))
+
+	debug, err := layout.Debug(bento.Rect{Width: 10, Height: 1})
+	require.NoError(t, err)
+
+	require.Len(t, debug.Segments, 3)
+
+	require.True(t, debug.Segments[0].Satisfied)
+	require.True(t, debug.Segments[2].Satisfied)
+
+	percentage := debug.Segments[1]
+	require.False(t, percentage.Satisfied)
+	require.Equal(t, 1, percentage.Size)
+	require.Equal(t, 5, percentage.Requested)
+	require.Equal(t, "percentage == (strong)", percentage.Strength)
+	require.Equal(t, []string{
+		"area bounds (required)",
+		"spacing 1 (required/10)",
+		"segment 0 Len(3): length == (strong*10)",
+		"segment 2 Min(4): min size >= (strong*100)",
+	}, percentage.OverriddenBy)
+
+	var output bytes.Buffer
+
+	bento.SetLayoutDebugOutput(&output)
+	defer bento.SetLayoutDebugOutput(nil)
+
+	// the report is returned, not written
+	_, err = layout.Debug(bento.Rect{Width: 10, Height: 1})
+	require.NoError(t, err)
+	require.Empty(t, output.String())
+
+	segments, err := layout.TrySplit(bento.Rect{Width: 10, Height: 1})
+	require.NoError(t, err)
+	require.Len(t, segments, 3)
+	require.Equal(t, debug.String(), output.String())
+}
+
+func TestLayout_DebugSlackConstraints(t *testing.T) {
+	debug, err := bento.NewLayout(
+		bento.ConstraintLen(3),
+		bento.ConstraintPercentage(50),
+		bento.ConstraintLen(6),
+		bento.ConstraintMax(5),
+	).
+		Horizontal().
+		WithRelations(bento.SegmentSize(1).LessThanEqual(bento.ConstSize(9))).
+		Debug(bento.Rect{Width: 10, Height: 1})
+	require.NoError(t, err)
+
+	percentage := debug.Segments[1]
+	require.False(t, percentage.Satisfied)
+	require.Equal(t, 1, percentage.Size)
+
+	// the max size and the relation are not reached, so they did not decide the size
+	require.Equal(t, []string{
+		"area bounds (required)",
+		"segment 0 Len(3): length == (strong*10)",
+		"segment 2 Len(6): length == (strong*10)",
+	}, percentage.OverriddenBy)
+}
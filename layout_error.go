@@ -0,0 +1,34 @@
+package bento
+
+import (
+	"fmt"
+
+	"github.com/metafates/bento/internal/casso"
+)
+
+// Errors of the constraint solver a [LayoutError] may wrap.
+var (
+	ErrDuplicateConstraint     = casso.ErrDuplicateConstraint
+	ErrUnsatisfiableConstraint = casso.ErrUnsatisfiableConstraint
+)
+
+// InternalSolverError is an internal error of the constraint solver, e.g. unbounded objective.
+type InternalSolverError = casso.InternalSolverError
+
+// LayoutError is returned when the layout constraints can not be solved.
+type LayoutError struct {
+	Direction   Direction
+	Constraints []Constraint
+	Area        Rect
+
+	// Err is the solver error, e.g. [InternalSolverError] or [ErrUnsatisfiableConstraint].
+	Err error
+}
+
+func (e *LayoutError) Error() string {
+	return fmt.Sprintf("split %+v by %v: %v", e.Area, e.Constraints, e.Err)
+}
+
+func (e *LayoutError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,323 @@
+package bento
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/metafates/bento/internal/casso"
+)
+
+// Strength is the strength of a layout [Relation].
+// When relations conflict, the stronger one wins.
+//
+// Strengths can be scaled, e.g. StrengthStrong * 10.
+type Strength float64
+
+const (
+	StrengthRequired = Strength(casso.Required)
+	StrengthStrong   = Strength(casso.Strong)
+	StrengthMedium   = Strength(casso.Medium)
+	StrengthWeak     = Strength(casso.Weak)
+)
+
+func (s Strength) String() string {
+	switch s {
+	case StrengthRequired:
+		return "required"
+	case StrengthStrong:
+		return "strong"
+	case StrengthMedium:
+		return "medium"
+	case StrengthWeak:
+		return "weak"
+	default:
+		return strconv.FormatFloat(float64(s), 'g', -1, 64)
+	}
+}
+
+// SizeExpr is a linear expression of layout sizes, in cells.
+// It is used to build relations between layout segments, see [Layout.WithRelations],
+// and between grid rows and columns, see [Grid.WithRelations].
+type SizeExpr struct {
+	terms    []sizeTerm
+	constant float64
+}
+
+type sizeTerm struct {
+	kind        sizeKind
+	index       int
+	coefficient float64
+}
+
+// sizeKind is what the size in a [SizeExpr] is of.
+type sizeKind int
+
+const (
+	sizeSegment sizeKind = iota
+	sizeArea
+	sizeRow
+	sizeColumn
+)
+
+// SegmentSize returns the size of the layout segment with the given index.
+func SegmentSize(index int) SizeExpr {
+	return SizeExpr{terms: []sizeTerm{{kind: sizeSegment, index: index, coefficient: 1}}}
+}
+
+// AreaSize returns the size of the whole layout area, excluding padding.
+func AreaSize() SizeExpr {
+	return SizeExpr{terms: []sizeTerm{{kind: sizeArea, coefficient: 1}}}
+}
+
+// RowSize returns the height of the grid row with the given index, see [Grid.WithRelations].
+func RowSize(index int) SizeExpr {
+	return SizeExpr{terms: []sizeTerm{{kind: sizeRow, index: index, coefficient: 1}}}
+}
+
+// ColumnSize returns the width of the grid column with the given index, see [Grid.WithRelations].
+func ColumnSize(index int) SizeExpr {
+	return SizeExpr{terms: []sizeTerm{{kind: sizeColumn, index: index, coefficient: 1}}}
+}
+
+// ConstSize returns the constant size.
+func ConstSize(size float64) SizeExpr {
+	return SizeExpr{constant: size}
+}
+
+func (e SizeExpr) Add(other SizeExpr) SizeExpr {
+	return SizeExpr{
+		terms:    append(append([]sizeTerm{}, e.terms...), other.terms...),
+		constant: e.constant + other.constant,
+	}
+}
+
+func (e SizeExpr) Sub(other SizeExpr) SizeExpr {
+	return e.Add(other.Mul(-1))
+}
+
+func (e SizeExpr) Mul(factor float64) SizeExpr {
+	terms := make([]sizeTerm, len(e.terms))
+
+	for i, t := range e.terms {
+		terms[i] = sizeTerm{kind: t.kind, index: t.index, coefficient: t.coefficient * factor}
+	}
+
+	return SizeExpr{terms: terms, constant: e.constant * factor}
+}
+
+func (e SizeExpr) Div(divisor float64) SizeExpr {
+	return e.Mul(1 / divisor)
+}
+
+// Equal returns the relation requiring both expressions to be equal.
+func (e SizeExpr) Equal(other SizeExpr) Relation {
+	return newRelation(e, casso.RelationOperatorEqual, other)
+}
+
+// LessThanEqual returns the relation requiring the expression to be at most the other one.
+func (e SizeExpr) LessThanEqual(other SizeExpr) Relation {
+	return newRelation(e, casso.RelationOperatorLessThanEqual, other)
+}
+
+// GreaterThanEqual returns the relation requiring the expression to be at least the other one.
+func (e SizeExpr) GreaterThanEqual(other SizeExpr) Relation {
+	return newRelation(e, casso.RelationOperatorGreaterThanEqual, other)
+}
+
+func (e SizeExpr) String() string {
+	var parts []string
+
+	for _, t := range e.terms {
+		var name string
+
+		switch t.kind {
+		case sizeSegment:
+			name = fmt.Sprintf("Segment(%d)", t.index)
+		case sizeArea:
+			name = "Area"
+		case sizeRow:
+			name = fmt.Sprintf("Row(%d)", t.index)
+		case sizeColumn:
+			name = fmt.Sprintf("Column(%d)", t.index)
+		}
+
+		if t.coefficient != 1 {
+			name += "*" + strconv.FormatFloat(t.coefficient, 'g', -1, 64)
+		}
+
+		parts = append(parts, name)
+	}
+
+	if e.constant != 0 || len(parts) == 0 {
+		parts = append(parts, strconv.FormatFloat(e.constant, 'g', -1, 64))
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+func (e SizeExpr) segments() []int {
+	var segments []int
+
+	for _, t := range e.terms {
+		if t.kind == sizeSegment {
+			segments = append(segments, t.index)
+		}
+	}
+
+	return segments
+}
+
+// sizes resolves the sizes a [SizeExpr] refers to into the solver expressions.
+type sizes func(t sizeTerm) (casso.Expression, error)
+
+// layoutSizes resolves the sizes of a [Layout] area and its segments.
+func layoutSizes(area _Element, segments []_Element) sizes {
+	return func(t sizeTerm) (casso.Expression, error) {
+		switch t.kind {
+		case sizeArea:
+			return area.size(), nil
+		case sizeSegment:
+			return elementSize("segment", t.index, segments)
+		default:
+			return casso.Expression{}, fmt.Errorf("%s is not a layout size", SizeExpr{terms: []sizeTerm{t}})
+		}
+	}
+}
+
+// elementSize returns the size of the element with the given index.
+func elementSize(name string, index int, elements []_Element) (casso.Expression, error) {
+	if index < 0 || index >= len(elements) {
+		return casso.Expression{}, fmt.Errorf("%s %d out of range [0, %d)", name, index, len(elements))
+	}
+
+	return elements[index].size(), nil
+}
+
+func (e SizeExpr) expression(sizes sizes) (casso.Expression, error) {
+	expression := casso.NewExpressionFromConstant(e.constant * _floatPrecisionMultiplier)
+
+	for _, t := range e.terms {
+		size, err := sizes(t)
+		if err != nil {
+			return casso.Expression{}, err
+		}
+
+		size = size.MulConstant(t.coefficient)
+
+		expression.Terms = append(expression.Terms, size.Terms...)
+		expression.Constant += size.Constant
+	}
+
+	return expression, nil
+}
+
+// eval returns the value of the expression for the given sizes.
+func (e SizeExpr) eval(size func(t sizeTerm) float64) float64 {
+	value := e.constant
+
+	for _, t := range e.terms {
+		value += size(t) * t.coefficient
+	}
+
+	return value
+}
+
+// Relation is a linear relation between layout sizes, e.g.
+//
+//	// the third segment is as large as the first one
+//	bento.SegmentSize(2).Equal(bento.SegmentSize(0))
+//
+//	// the sidebar is at most a third of the main pane
+//	bento.SegmentSize(0).LessThanEqual(bento.SegmentSize(1).Div(3))
+//
+// Relations are [StrengthRequired] by default, which makes [Layout.Split] panic
+// if they can not be satisfied. Use [Relation.WithStrength] for preferences, e.g.
+//
+//	// at least 10 but prefer 20
+//	bento.SegmentSize(0).GreaterThanEqual(bento.ConstSize(10)),
+//	bento.SegmentSize(0).Equal(bento.ConstSize(20)).WithStrength(bento.StrengthMedium),
+type Relation struct {
+	lhs, rhs SizeExpr
+	operator casso.RelationOperator
+	strength Strength
+}
+
+func newRelation(lhs SizeExpr, operator casso.RelationOperator, rhs SizeExpr) Relation {
+	return Relation{
+		lhs:      lhs,
+		rhs:      rhs,
+		operator: operator,
+		strength: StrengthRequired,
+	}
+}
+
+func (r Relation) WithStrength(strength Strength) Relation {
+	r.strength = strength
+	return r
+}
+
+func (r Relation) Strength() Strength {
+	return r.strength
+}
+
+func (r Relation) String() string {
+	var operator string
+
+	switch r.operator {
+	case casso.RelationOperatorEqual:
+		operator = "=="
+	case casso.RelationOperatorLessThanEqual:
+		operator = "<="
+	case casso.RelationOperatorGreaterThanEqual:
+		operator = ">="
+	}
+
+	return fmt.Sprintf("%s %s %s (%s)", r.lhs, operator, r.rhs, r.strength)
+}
+
+// involves reports whether the relation refers to the segment.
+func (r Relation) involves(segment int) bool {
+	for _, s := range append(r.lhs.segments(), r.rhs.segments()...) {
+		if s == segment {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Relation) constraint(sizes sizes) (casso.Constraint, error) {
+	lhs, err := r.lhs.expression(sizes)
+	if err != nil {
+		return nil, fmt.Errorf("lhs: %w", err)
+	}
+
+	rhs, err := r.rhs.expression(sizes)
+	if err != nil {
+		return nil, fmt.Errorf("rhs: %w", err)
+	}
+
+	relation := casso.WeightedRelation{Operator: r.operator, Strength: casso.Strength(r.strength)}
+
+	return relation.ExpressionLHS(lhs).ExpressionRHS(rhs), nil
+}
+
+func configureRelations(
+	solver *casso.Solver,
+	sizes sizes,
+	relations []Relation,
+) error {
+	for _, r := range relations {
+		constraint, err := r.constraint(sizes)
+		if err != nil {
+			return fmt.Errorf("relation %s: %w", r, err)
+		}
+
+		if err := solver.AddConstraint(constraint); err != nil {
+			return fmt.Errorf("add relation %s: %w", r, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package bento_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayout_WithRelations(t *testing.T) {
+	testCases := []struct {
+		name        string
+		constraints []bento.Constraint
+		relations   []bento.Relation
+		width       int
+		want        []int
+	}{
+		{
+			name:        "segment equals another segment",
+			constraints: []bento.Constraint{bento.ConstraintLen(5), bento.ConstraintFill(1), bento.ConstraintMin(0)},
+			relations:   []bento.Relation{bento.SegmentSize(2).Equal(bento.SegmentSize(0))},
+			width:       20,
+			want:        []int{5, 10, 5},
+		},
+		{
+			name:        "at most a third of another segment",
+			constraints: []bento.Constraint{bento.ConstraintFill(1), bento.ConstraintFill(1)},
+			relations:   []bento.Relation{bento.SegmentSize(0).LessThanEqual(bento.SegmentSize(1).Div(3))},
+			width:       20,
+			want:        []int{5, 15},
+		},
+		{
+			name:        "at least but prefer",
+			constraints: []bento.Constraint{bento.ConstraintMin(0), bento.ConstraintFill(1)},
+			relations: []bento.Relation{
+				bento.SegmentSize(0).GreaterThanEqual(bento.ConstSize(10)),
+				bento.SegmentSize(0).Equal(bento.ConstSize(20)).WithStrength(bento.StrengthStrong),
+			},
+			width: 30,
+			want:  []int{20, 10},
+		},
+		{
+			name:        "at least but prefer with small area",
+			constraints: []bento.Constraint{bento.ConstraintMin(0), bento.ConstraintFill(1)},
+			relations: []bento.Relation{
+				bento.SegmentSize(0).GreaterThanEqual(bento.ConstSize(10)),
+				bento.SegmentSize(0).Equal(bento.ConstSize(20)).WithStrength(bento.StrengthStrong),
+			},
+			width: 15,
+			want:  []int{15, 0},
+		},
+		{
+			name:        "relative to area",
+			constraints: []bento.Constraint{bento.ConstraintFill(1), bento.ConstraintFill(1)},
+			relations:   []bento.Relation{bento.SegmentSize(1).Equal(bento.AreaSize().Sub(bento.ConstSize(4)))},
+			width:       20,
+			want:        []int{4, 16},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			segments, err := bento.NewLayout(tc.constraints...).
+				Horizontal().
+				WithRelations(tc.relations...).
+				TrySplit(bento.Rect{Width: tc.width, Height: 1})
+			require.NoError(t, err)
+
+			sizes := make([]int, 0, len(segments))
+			for _, s := range segments {
+				sizes = append(sizes, s.Width)
+			}
+
+			require.Equal(t, tc.want, sizes)
+		})
+	}
+}
+
+func TestLayout_TrySplit_Error(t *testing.T) {
+	area := bento.Rect{Width: 10, Height: 1}
+
+	_, err := bento.NewLayout(bento.ConstraintFill(1)).
+		Horizontal().
+		WithRelations(bento.SegmentSize(0).GreaterThanEqual(bento.ConstSize(20))).
+		TrySplit(area)
+
+	var layoutErr *bento.LayoutError
+	require.True(t, errors.As(err, &layoutErr))
+	require.Equal(t, area, layoutErr.Area)
+	require.ErrorIs(t, err, bento.ErrUnsatisfiableConstraint)
+
+	_, err = bento.NewLayout(bento.ConstraintFill(1)).
+		WithRelations(bento.SegmentSize(1).Equal(bento.SegmentSize(0))).
+		TrySplit(area)
+	require.ErrorContains(t, err, "segment 1 out of range")
+
+	require.Panics(t, func() {
+		bento.NewLayout(bento.ConstraintFill(1)).
+			WithRelations(bento.SegmentSize(1).Equal(bento.SegmentSize(0))).
+			Split(area)
+	})
+}
@@ -0,0 +1,37 @@
+package listwidget
+
+import "github.com/metafates/bento/keymap"
+
+var _ keymap.KeyMap = (*KeyMap)(nil)
+
+// KeyMap is the key bindings of the list [State].
+type KeyMap struct {
+	SelectNext     keymap.Binding
+	SelectPrevious keymap.Binding
+	SelectFirst    keymap.Binding
+	SelectLast     keymap.Binding
+	ScrollDown     keymap.Binding
+	ScrollUp       keymap.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		SelectNext:     keymap.NewBinding("j", "down").WithHelp("↓/j", "down"),
+		SelectPrevious: keymap.NewBinding("k", "up").WithHelp("↑/k", "up"),
+		SelectFirst:    keymap.NewBinding("g").WithHelp("g", "first"),
+		SelectLast:     keymap.NewBinding("G").WithHelp("G", "last"),
+		ScrollDown:     keymap.NewBinding("ctrl+d").WithHelp("ctrl+d", "page down"),
+		ScrollUp:       keymap.NewBinding("ctrl+u").WithHelp("ctrl+u", "page up"),
+	}
+}
+
+func (k KeyMap) ShortHelp() []keymap.Binding {
+	return []keymap.Binding{k.SelectPrevious, k.SelectNext}
+}
+
+func (k KeyMap) FullHelp() [][]keymap.Binding {
+	return [][]keymap.Binding{
+		{k.SelectPrevious, k.SelectNext, k.ScrollUp, k.ScrollDown},
+		{k.SelectFirst, k.SelectLast},
+	}
+}
@@ -5,7 +5,7 @@ import (
 
 	"github.com/metafates/bento"
 	"github.com/metafates/bento/blockwidget"
-	"github.com/metafates/bento/internal/sliceutil"
+	"github.com/metafates/bento/internal/scroll"
 	"github.com/metafates/bento/textwidget"
 	"github.com/rivo/uniseg"
 )
@@ -20,22 +20,13 @@ const (
 type HighlightSpacing int
 
 const (
-	HighlightSpacingWhenSelected HighlightSpacing = iota
-	HighlightSpacingAlways
-	HighlightSpacingNever
+	HighlightSpacingWhenSelected HighlightSpacing = scroll.HighlightSpacingWhenSelected
+	HighlightSpacingAlways       HighlightSpacing = scroll.HighlightSpacingAlways
+	HighlightSpacingNever        HighlightSpacing = scroll.HighlightSpacingNever
 )
 
 func (hs HighlightSpacing) shouldAdd(hasSelection bool) bool {
-	switch hs {
-	case HighlightSpacingWhenSelected:
-		return hasSelection
-	case HighlightSpacingAlways:
-		return true
-	case HighlightSpacingNever:
-		return false
-	default:
-		return false
-	}
+	return scroll.ShouldAddHighlightSpacing(int(hs), hasSelection)
 }
 
 var (
@@ -44,7 +35,7 @@ var (
 )
 
 type List struct {
-	items                 []textwidget.Text
+	source                ItemSource
 	block                 *blockwidget.Block
 	style                 bento.Style
 	direction             Direction
@@ -56,8 +47,13 @@ type List struct {
 }
 
 func New(items ...textwidget.Text) List {
+	return NewFromSource(Items(items))
+}
+
+// NewFromSource returns a new list rendering the items of the source on demand.
+func NewFromSource(source ItemSource) List {
 	return List{
-		items:                 items,
+		source:                source,
 		block:                 nil,
 		style:                 bento.NewStyle(),
 		direction:             DirectionTopToBottom,
@@ -70,7 +66,12 @@ func New(items ...textwidget.Text) List {
 }
 
 func (l List) WithItems(items ...textwidget.Text) List {
-	l.items = items
+	l.source = Items(items)
+	return l
+}
+
+func (l List) WithSource(source ItemSource) List {
+	l.source = source
 	return l
 }
 
@@ -88,12 +89,14 @@ func (l List) RenderStateful(area bento.Rect, buffer *bento.Buffer, state *State
 		listArea = l.block.Inner(area)
 	}
 
-	if listArea.IsEmpty() || len(l.items) == 0 {
+	itemsCount := l.len()
+
+	if listArea.IsEmpty() || itemsCount == 0 {
 		return
 	}
 
-	if state.selected != nil && *state.selected >= len(l.items) {
-		state.Select(max(0, len(l.items)-1))
+	if state.selected != nil && *state.selected >= itemsCount {
+		state.Select(max(0, itemsCount-1))
 	}
 
 	listHeight := listArea.Height
@@ -111,8 +114,8 @@ func (l List) RenderStateful(area bento.Rect, buffer *bento.Buffer, state *State
 
 	selectionSpacing := l.highlightSpacing.shouldAdd(state.selected != nil)
 
-	for i, item := range sliceutil.Take(sliceutil.Skip(l.items, state.offset), lastVisibleIndex-firstVisibleIndex) {
-		i += state.offset
+	for i := firstVisibleIndex; i < lastVisibleIndex; i++ {
+		item := l.source.Item(i)
 
 		var x, y int
 
@@ -215,118 +218,23 @@ func (l List) WithBlock(block blockwidget.Block) List {
 	return l
 }
 
-// getItemsBounds given an offset, calculates which items can fit in a given area
-func (l List) getItemsBounds(selected *int, offset, maxHeight int) (int, int) {
-	offset = min(offset, max(0, len(l.items)-1))
-
-	// NOTE: visible here implies visible in the given area
-	firstVisibleIndex := offset
-	lastVisibleIndex := offset
-
-	// Current height of all items in the list to render, beginning at the offset
-	var heightFromOffset int
-
-	// Calculate the last visible index and total height of the items
-	// that will fit in the available space
-	for _, item := range sliceutil.Skip(l.items, offset) {
-		if heightFromOffset+item.Height() > maxHeight {
-			break
-		}
-
-		heightFromOffset += item.Height()
-
-		lastVisibleIndex++
-	}
-
-	// Get the selected index and apply scroll_padding to it, but still honor the offset if
-	// nothing is selected. This allows for the list to stay at a position after selecting
-	// None.
-	indexToDisplay := offset
-	if selected != nil {
-		indexToDisplay = l.applyScrollPaddingToSelectedIndex(
-			*selected,
-			maxHeight,
-			firstVisibleIndex,
-			lastVisibleIndex,
-		)
-	}
-
-	// Recall that last_visible_index is the index of what we
-	// can render up to in the given space after the offset
-	// If we have an item selected that is out of the viewable area (or
-	// the offset is still set), we still need to show this item
-	for indexToDisplay >= lastVisibleIndex {
-		heightFromOffset += l.items[lastVisibleIndex].Height()
-
-		lastVisibleIndex++
-
-		// Now we need to hide previous items since we didn't have space
-		// for the selected/offset item
-		for heightFromOffset > maxHeight {
-			heightFromOffset = max(0, heightFromOffset-l.items[firstVisibleIndex].Height())
-
-			// Remove this item to view by starting at the next item index
-			firstVisibleIndex++
-		}
+func (l List) len() int {
+	if l.source == nil {
+		return 0
 	}
 
-	// Here we're doing something similar to what we just did above
-	// If the selected item index is not in the viewable area, let's try to show the item
-	for indexToDisplay < firstVisibleIndex {
-		firstVisibleIndex--
-
-		heightFromOffset += l.items[firstVisibleIndex].Height()
-
-		// Don't show an item if it is beyond our viewable height
-		for heightFromOffset > maxHeight {
-			lastVisibleIndex--
-
-			heightFromOffset = max(0, heightFromOffset-l.items[lastVisibleIndex].Height())
-		}
-	}
-
-	return firstVisibleIndex, lastVisibleIndex
+	return l.source.Len()
 }
 
-// applyScrollPaddingToSelectedIndex applies scroll padding to the selected index, reducing the padding value to keep the
-// selected item on screen even with items of inconsistent sizes
-//
-// This function is sensitive to how the bounds checking function handles item height
-func (l List) applyScrollPaddingToSelectedIndex(selected int, maxHeight, firstVisibleIndex, lastVisibleIndex int) int {
-	lastValidIndex := max(0, len(l.items)-1)
-
-	selected = min(selected, lastValidIndex)
-
-	// The below loop handles situations where the list item sizes may not be consistent,
-	// where the offset would have excluded some items that we want to include, or could
-	// cause the offset value to be set to an inconsistent value each time we render.
-	// The padding value will be reduced in case any of these issues would occur
-	scrollPadding := l.scrollPadding
-
-	for scrollPadding > 0 {
-		var heightAroundSelected int
-
-		from := max(0, selected-scrollPadding)
-		to := min(lastValidIndex, selected+scrollPadding)
-
-		for i := from; i <= to; i++ {
-			heightAroundSelected += l.items[i].Height()
-		}
-
-		if heightAroundSelected <= maxHeight {
-			break
-		}
-
-		scrollPadding--
-	}
-
-	res := selected
-
-	if min(lastValidIndex, selected+scrollPadding) >= lastVisibleIndex {
-		res = selected + scrollPadding
-	} else if max(0, selected-scrollPadding) < firstVisibleIndex {
-		res = max(0, selected-scrollPadding)
-	}
+func (l List) itemHeight(index int) int {
+	return l.source.Item(index).Height()
+}
 
-	return min(res, lastValidIndex)
+// getItemsBounds given an offset, calculates which items can fit in a given area
+func (l List) getItemsBounds(selected *int, offset, maxHeight int) (int, int) {
+	return scroll.Items{
+		Count:         l.len(),
+		Height:        l.itemHeight,
+		ScrollPadding: l.scrollPadding,
+	}.Bounds(selected, offset, maxHeight)
 }
@@ -1,9 +1,11 @@
 package listwidget
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
 	"github.com/metafates/bento/textwidget"
 	"github.com/stretchr/testify/require"
 )
@@ -42,3 +44,94 @@ func statefulWidget(widget List, state *State, width, height int) bento.Buffer {
 
 	return buffer
 }
+
+// countingSource is a lazy source of items with variable heights
+// that records which items were requested.
+type countingSource struct {
+	count     int
+	requested map[int]struct{}
+}
+
+func (s *countingSource) Len() int {
+	return s.count
+}
+
+func (s *countingSource) Item(index int) textwidget.Text {
+	s.requested[index] = struct{}{}
+
+	lines := []string{fmt.Sprintf("Item %d", index)}
+
+	// every third item is two lines tall
+	if index%3 == 0 {
+		lines = append(lines, "  more")
+	}
+
+	return textwidget.NewText(textwidget.NewLinesStr(lines...)...)
+}
+
+func TestSourceRequestsOnlyVisibleItems(t *testing.T) {
+	source := &countingSource{count: 100_000, requested: make(map[int]struct{})}
+
+	list := NewFromSource(source).WithHighlightSymbol(">")
+	state := NewState()
+	state.SelectLast()
+
+	buffer := statefulWidget(list, &state, 12, 4)
+
+	bentotest.AssertBufferLines(t, []string{
+		" Item 99997 ",
+		" Item 99998 ",
+		">Item 99999 ",
+		"   more     ",
+	}, buffer)
+
+	selected, ok := state.Selected(source.count - 1)
+	require.True(t, ok)
+	require.Equal(t, 99_999, selected)
+	require.LessOrEqual(t, len(source.requested), 10)
+}
+
+func TestSelectedItemEnsuresVisibleOffsetAfterRange(t *testing.T) {
+	items := []textwidget.Text{
+		textwidget.NewTextStr("Item 0"),
+		textwidget.NewTextStr("Item 1"),
+		textwidget.NewTextStr("Item 2"),
+		textwidget.NewTextStr("Item 3\nmore"),
+		textwidget.NewTextStr("Item 4"),
+	}
+
+	list := New(items...).WithHighlightSymbol(">>")
+	state := NewState()
+	state.Select(3)
+
+	buffer := statefulWidget(list, &state, 10, 4)
+
+	bentotest.AssertBufferLines(t, []string{
+		"  Item 1  ",
+		"  Item 2  ",
+		">>Item 3  ",
+		"  more    ",
+	}, buffer)
+}
+
+func TestState_KeyMap(t *testing.T) {
+	state := NewState()
+
+	keyMap := state.KeyMap()
+	keyMap.SelectNext = keyMap.SelectNext.WithKeys("n")
+	keyMap.SelectLast = keyMap.SelectLast.WithEnabled(false)
+	state.SetKeyMap(keyMap)
+
+	handled, _ := state.TryUpdate(bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune("j")})
+	require.False(t, handled)
+
+	handled, _ = state.TryUpdate(bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune("G")})
+	require.False(t, handled)
+
+	handled, _ = state.TryUpdate(bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune("n")})
+	require.True(t, handled)
+
+	selected, ok := state.Selected(10)
+	require.True(t, ok)
+	require.Equal(t, 0, selected)
+}
@@ -0,0 +1,32 @@
+package listwidget
+
+import "github.com/metafates/bento/textwidget"
+
+// ItemSource provides the list items on demand.
+//
+// Only the items around the visible range are requested on render,
+// so that lists with a large number of items don't have to be materialized up front.
+// Items may have different heights.
+type ItemSource interface {
+	// Len returns the number of items.
+	Len() int
+
+	// Item returns the item at the given index.
+	// It may be called multiple times for the same item during a single render.
+	Item(index int) textwidget.Text
+}
+
+var _ ItemSource = Items(nil)
+
+// Items is the [ItemSource] of already materialized items.
+type Items []textwidget.Text
+
+// Len implements ItemSource.
+func (i Items) Len() int {
+	return len(i)
+}
+
+// Item implements ItemSource.
+func (i Items) Item(index int) textwidget.Text {
+	return i[index]
+}
@@ -5,11 +5,13 @@ import (
 
 	"github.com/metafates/bento"
 	"github.com/metafates/bento/inputwidget"
+	"github.com/metafates/bento/keymap"
 )
 
 type State struct {
 	offset   int
 	selected *int
+	keyMap   *KeyMap
 }
 
 func NewState() State {
@@ -19,9 +21,23 @@ func NewState() State {
 	return State{
 		offset:   0,
 		selected: nil,
+		keyMap:   nil,
 	}
 }
 
+// KeyMap returns the key bindings of the state, [DefaultKeyMap] unless set with [State.SetKeyMap].
+func (s *State) KeyMap() KeyMap {
+	if s.keyMap == nil {
+		return DefaultKeyMap()
+	}
+
+	return *s.keyMap
+}
+
+func (s *State) SetKeyMap(keyMap KeyMap) {
+	s.keyMap = &keyMap
+}
+
 func (s *State) TryUpdate(msg bento.Msg) (bool, bento.Cmd) {
 	keyMsg, ok := msg.(bento.KeyMsg)
 	if !ok {
@@ -32,28 +48,30 @@ func (s *State) TryUpdate(msg bento.Msg) (bool, bento.Cmd) {
 }
 
 func (s *State) update(key bento.Key) bool {
-	switch key.String() {
-	case "ctrl+u":
+	keyMap := s.KeyMap()
+
+	switch {
+	case keymap.Matches(key, keyMap.ScrollUp):
 		s.ScrollUpBy(8)
 		return true
 
-	case "ctrl+d":
+	case keymap.Matches(key, keyMap.ScrollDown):
 		s.ScrollDownBy(8)
 		return true
 
-	case "G":
+	case keymap.Matches(key, keyMap.SelectLast):
 		s.SelectLast()
 		return true
 
-	case "g":
+	case keymap.Matches(key, keyMap.SelectFirst):
 		s.SelectFirst()
 		return true
 
-	case "j", "down":
+	case keymap.Matches(key, keyMap.SelectNext):
 		s.SelectNext()
 		return true
 
-	case "k", "up":
+	case keymap.Matches(key, keyMap.SelectPrevious):
 		s.SelectPrevious()
 		return true
 
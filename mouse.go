@@ -2,6 +2,15 @@ package bento
 
 import "strconv"
 
+// mouseMode is the kind of mouse events reporting enabled in the terminal.
+type mouseMode int
+
+const (
+	mouseModeNone mouseMode = iota
+	mouseModeCellMotion
+	mouseModeAllMotion
+)
+
 // MouseMsg contains information about a mouse event and are sent to a programs
 // update function when mouse activity occurs. Note that the mouse must first
 // be enabled in order for the mouse events to be received.
@@ -0,0 +1,98 @@
+package bento_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/stretchr/testify/require"
+)
+
+type initModel struct {
+	init bento.Cmd
+}
+
+func (m initModel) Init() bento.Cmd {
+	return m.init
+}
+
+func (m initModel) Update(bento.Msg) (bento.Model, bento.Cmd) {
+	return m, nil
+}
+
+func (initModel) Render(bento.Rect, *bento.Buffer) {}
+
+// runOutput runs the app with the given init command and returns its output.
+func runOutput(t *testing.T, app func(model bento.Model) bento.App, init bento.Cmd) string {
+	t.Helper()
+
+	var output bytes.Buffer
+
+	_, err := app(initModel{init: init}).
+		WithInput(nil).
+		WithOutput(&output).
+		Run()
+	require.NoError(t, err)
+
+	return output.String()
+}
+
+// requireInOrder fails the test unless all the sequences are in the output in the given order.
+func requireInOrder(t *testing.T, output string, sequences ...string) {
+	t.Helper()
+
+	for _, seq := range sequences {
+		i := strings.Index(output, seq)
+		require.NotEqual(t, -1, i, "%q not found in the output", seq)
+
+		output = output[i+len(seq):]
+	}
+}
+
+func TestApp_WithMouse(t *testing.T) {
+	output := runOutput(t, func(model bento.Model) bento.App {
+		return bento.NewApp(model).WithMouseCellMotion()
+	}, bento.Quit)
+
+	requireInOrder(t, output, "\x1b[?1002h", "\x1b[?1006h", "\x1b[?1002l", "\x1b[?1006l")
+
+	output = runOutput(t, func(model bento.Model) bento.App {
+		return bento.NewApp(model).WithMouseAllMotion()
+	}, bento.Quit)
+
+	requireInOrder(t, output, "\x1b[?1003h", "\x1b[?1006h", "\x1b[?1003l", "\x1b[?1006l")
+
+	// not enabled by default
+	output = runOutput(t, bento.NewApp, bento.Quit)
+
+	require.NotContains(t, output, "\x1b[?1002h")
+	require.NotContains(t, output, "\x1b[?1003h")
+	require.NotContains(t, output, "\x1b[?1006l")
+}
+
+func TestApp_EnableMouse(t *testing.T) {
+	// enabled at runtime and disabled on exit
+	output := runOutput(t, bento.NewApp, bento.Sequence(bento.EnableMouseAllMotion, bento.Quit))
+
+	requireInOrder(t, output, "\x1b[?1003h", "\x1b[?1006h", "\x1b[?1003l", "\x1b[?1006l")
+}
+
+func TestTerminal_Mouse(t *testing.T) {
+	backend := bentotest.NewBackend(10, 2)
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportFullscreen{})
+	require.NoError(t, err)
+
+	require.NoError(t, terminal.HandleMsg(bento.EnableMouseCellMotion()))
+	require.Equal(t, bentotest.MouseModeCellMotion, backend.MouseMode())
+	require.True(t, terminal.MouseEnabled())
+
+	require.NoError(t, terminal.HandleMsg(bento.EnableMouseAllMotion()))
+	require.Equal(t, bentotest.MouseModeAllMotion, backend.MouseMode())
+
+	require.NoError(t, terminal.HandleMsg(bento.DisableMouse()))
+	require.Equal(t, bentotest.MouseModeNone, backend.MouseMode())
+	require.False(t, terminal.MouseEnabled())
+}
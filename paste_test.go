@@ -0,0 +1,70 @@
+package bento_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+type pasteModel struct {
+	msgs []bento.Msg
+}
+
+func (m pasteModel) Init() bento.Cmd {
+	return nil
+}
+
+func (m pasteModel) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
+	switch msg := msg.(type) {
+	case bento.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, bento.Quit
+		}
+
+		m.msgs = append(m.msgs, msg.String())
+	case bento.PasteMsg:
+		m.msgs = append(m.msgs, msg)
+	}
+
+	return m, nil
+}
+
+func (pasteModel) Render(bento.Rect, *bento.Buffer) {}
+
+func readPastes(t *testing.T, app bento.App, input io.Reader) []bento.Msg {
+	t.Helper()
+
+	model, err := app.
+		WithInput(input).
+		WithOutput(&bytes.Buffer{}).
+		Run()
+	require.NoError(t, err)
+
+	return model.(pasteModel).msgs
+}
+
+func TestPasteMsg(t *testing.T) {
+	input := "a\x1b[200~first line\r\nj\x1b[A\x1b[201~b\x03"
+
+	msgs := readPastes(t, bento.NewApp(pasteModel{}), strings.NewReader(input))
+	require.Equal(t, []bento.Msg{"a", bento.PasteMsg("first line\r\nj\x1b[A"), "b"}, msgs)
+
+	// the paste and its end sequence span many reads
+	msgs = readPastes(t, bento.NewApp(pasteModel{}), io.MultiReader(
+		strings.NewReader("a\x1b[200~first li"),
+		strings.NewReader("ne\r\nj\x1b[A\x1b[2"),
+		strings.NewReader("01~b\x03"),
+	))
+	require.Equal(t, []bento.Msg{"a", bento.PasteMsg("first line\r\nj\x1b[A"), "b"}, msgs)
+}
+
+func TestPasteMsg_MaxSize(t *testing.T) {
+	input := "\x1b[200~" + strings.Repeat("x", 1000) + "\x1b[201~\x03"
+
+	msgs := readPastes(t, bento.NewApp(pasteModel{}).WithMaxPasteSize(300), strings.NewReader(input))
+	require.Equal(t, []bento.Msg{bento.PasteMsg(strings.Repeat("x", 300))}, msgs)
+}
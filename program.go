@@ -0,0 +1,74 @@
+package bento
+
+import (
+	"context"
+)
+
+// Program is a handle to the running [App].
+// It is safe to use from multiple goroutines, e.g. to deliver
+// messages from network streams or file watchers.
+type Program struct {
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+
+	msgs     chan Msg
+	finished chan struct{}
+
+	model Model
+	err   error
+}
+
+// Start runs the app in a new goroutine and returns a handle to it.
+//
+// Use [Program.Wait] to wait for the app to exit and get the final model.
+func (a App) Start() *Program {
+	p := &Program{
+		ctx:       a.ctx,
+		cancelCtx: a.cancelCtx,
+		msgs:      make(chan Msg),
+		finished:  make(chan struct{}),
+	}
+
+	go func() {
+		defer close(p.finished)
+
+		p.model, p.err = a.run(p.msgs)
+	}()
+
+	return p
+}
+
+// Send sends a message to the app, as if it was returned by a [Cmd].
+//
+// It blocks until the message is received by the event loop.
+// If the app has exited it's a no-op.
+func (p *Program) Send(msg Msg) {
+	select {
+	case <-p.ctx.Done():
+	case <-p.finished:
+	case p.msgs <- msg:
+	}
+}
+
+// Quit gracefully stops the app, the same way as the [Quit] command does.
+func (p *Program) Quit() {
+	p.Send(Quit())
+}
+
+// Kill stops the app immediately without waiting for the pending messages.
+// The terminal is restored and [Program.Wait] returns [ErrKilled].
+func (p *Program) Kill() {
+	p.cancelCtx()
+}
+
+// Wait blocks until the app exits and returns the final model.
+func (p *Program) Wait() (Model, error) {
+	<-p.finished
+
+	return p.model, p.err
+}
+
+// Done returns a channel that is closed when the app exits.
+func (p *Program) Done() <-chan struct{} {
+	return p.finished
+}
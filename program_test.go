@@ -0,0 +1,121 @@
+package bento_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/metafates/bento"
+	"github.com/stretchr/testify/require"
+)
+
+type recordMsg string
+
+type recordModel struct {
+	records []string
+}
+
+func (m recordModel) Init() bento.Cmd {
+	return nil
+}
+
+func (m recordModel) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
+	if msg, ok := msg.(recordMsg); ok {
+		m.records = append(m.records, string(msg))
+	}
+
+	return m, nil
+}
+
+func (recordModel) Render(bento.Rect, *bento.Buffer) {}
+
+func TestProgram_Send(t *testing.T) {
+	var output bytes.Buffer
+
+	program := bento.NewApp(recordModel{}).
+		WithInput(nil).
+		WithOutput(&output).
+		Start()
+
+	program.Send(recordMsg("first"))
+	program.Send(recordMsg("second"))
+	program.Quit()
+
+	model, err := program.Wait()
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, model.(recordModel).records)
+
+	// no-op once exited
+	program.Send(recordMsg("third"))
+
+	require.Contains(t, output.String(), "\x1b[?1049h")
+	require.Contains(t, output.String(), "\x1b[?2004h")
+}
+
+func TestProgram_Kill(t *testing.T) {
+	program := bento.NewApp(recordModel{}).
+		WithInput(nil).
+		WithOutput(&bytes.Buffer{}).
+		Start()
+
+	program.Kill()
+
+	_, err := program.Wait()
+	require.ErrorIs(t, err, bento.ErrKilled)
+}
+
+func TestProgram_KillBeforeWait(t *testing.T) {
+	program := bento.NewApp(recordModel{}).
+		WithInput(nil).
+		WithOutput(&bytes.Buffer{}).
+		Start()
+
+	program.Send(recordMsg("first"))
+	program.Kill()
+
+	select {
+	case <-program.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the app did not exit")
+	}
+
+	_, err := program.Wait()
+	require.ErrorIs(t, err, bento.ErrKilled)
+
+	// no-op once exited
+	program.Kill()
+
+	_, err = program.Wait()
+	require.ErrorIs(t, err, bento.ErrKilled)
+}
+
+func TestProgram_SendAfterDone(t *testing.T) {
+	program := bento.NewApp(recordModel{}).
+		WithInput(nil).
+		WithOutput(&bytes.Buffer{}).
+		Start()
+
+	program.Send(recordMsg("first"))
+	program.Quit()
+
+	<-program.Done()
+
+	sent := make(chan struct{})
+
+	go func() {
+		defer close(sent)
+
+		program.Send(recordMsg("second"))
+		program.Quit()
+	}()
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("send blocks once the app exited")
+	}
+
+	model, err := program.Wait()
+	require.NoError(t, err)
+	require.Equal(t, []string{"first"}, model.(recordModel).records)
+}
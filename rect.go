@@ -78,6 +78,22 @@ func (r Rect) Intersection(other Rect) Rect {
 	}
 }
 
+// Union returns the smallest rect containing both rects.
+func (r Rect) Union(other Rect) Rect {
+	x1 := min(r.X, other.X)
+	y1 := min(r.Y, other.Y)
+
+	x2 := max(r.Right(), other.Right())
+	y2 := max(r.Bottom(), other.Bottom())
+
+	return Rect{
+		X:      x1,
+		Y:      y1,
+		Width:  x2 - x1,
+		Height: y2 - y1,
+	}
+}
+
 func (r Rect) Contains(position Position) bool {
 	return position.X >= r.X && position.X < r.Right() && position.Y >= r.Y && position.Y < r.Bottom()
 }
@@ -9,6 +9,21 @@ import (
 	"syscall"
 )
 
+const suspendSupported = true
+
+// suspendProcess stops the process group, like ctrl+z does in a shell,
+// and waits until it is continued.
+func suspendProcess() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGCONT)
+
+	defer signal.Stop(sig)
+
+	_ = syscall.Kill(0, syscall.SIGTSTP)
+
+	<-sig
+}
+
 // listenForResize sends messages (or errors) when the terminal resizes.
 // Argument output should be the file descriptor for the terminal; usually
 // os.Stdout.
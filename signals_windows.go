@@ -3,6 +3,11 @@
 
 package bento
 
+// suspendSupported is false since windows does not implement job control signals.
+const suspendSupported = false
+
+func suspendProcess() {}
+
 // listenForResize is not available on windows because windows does not
 // implement syscall.SIGWINCH.
 func (a *appRunner) listenForResize(done chan struct{}) {
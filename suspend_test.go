@@ -0,0 +1,75 @@
+package bento_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/inputwidget"
+	"github.com/stretchr/testify/require"
+)
+
+type suspendModel struct {
+	input inputwidget.State
+	msgs  []string
+}
+
+func (m suspendModel) Init() bento.Cmd {
+	return nil
+}
+
+func (m suspendModel) Update(msg bento.Msg) (bento.Model, bento.Cmd) {
+	switch msg := msg.(type) {
+	case bento.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, bento.Quit
+		}
+
+		m.msgs = append(m.msgs, msg.String())
+	case bento.ResumeMsg:
+		m.msgs = append(m.msgs, "resume")
+	}
+
+	m.input.TryUpdate(msg)
+
+	return m, nil
+}
+
+func (suspendModel) Render(bento.Rect, *bento.Buffer) {}
+
+func runSuspendModel(t *testing.T, app func(model bento.Model) bento.App, input string, msgs ...bento.Msg) suspendModel {
+	t.Helper()
+
+	program := app(suspendModel{input: inputwidget.NewState()}).
+		WithInput(strings.NewReader(input)).
+		WithOutput(&bytes.Buffer{}).
+		Start()
+
+	for _, msg := range msgs {
+		program.Send(msg)
+	}
+
+	model, err := program.Wait()
+	require.NoError(t, err)
+
+	return model.(suspendModel)
+}
+
+func TestApp_CtrlZUndo(t *testing.T) {
+	// ctrl+z is received by the model when suspending with it is disabled
+	model := runSuspendModel(t, func(model bento.Model) bento.App {
+		return bento.NewApp(model).WithoutCtrlZSuspend()
+	}, "hello\x7f\x1a\x03")
+
+	require.Equal(t, "hello", model.input.String())
+	require.Equal(t, []string{"hello", "backspace", "ctrl+z"}, model.msgs)
+}
+
+func TestApp_CtrlZWithoutTerminal(t *testing.T) {
+	// there is no terminal to give back to the shell,
+	// so ctrl+z is a regular key and suspend is ignored
+	model := runSuspendModel(t, bento.NewApp, "\x1a\x03", bento.Suspend())
+
+	require.Equal(t, []string{"ctrl+z"}, model.msgs)
+}
@@ -0,0 +1,78 @@
+package tablewidget
+
+import "github.com/metafates/bento/keymap"
+
+var (
+	_ keymap.KeyMap = (*KeyMap)(nil)
+	_ keymap.KeyMap = (*SortFilterKeyMap)(nil)
+)
+
+// KeyMap is the key bindings of the table [State].
+type KeyMap struct {
+	SelectNext           keymap.Binding
+	SelectPrevious       keymap.Binding
+	SelectFirst          keymap.Binding
+	SelectLast           keymap.Binding
+	ScrollDown           keymap.Binding
+	ScrollUp             keymap.Binding
+	SelectNextColumn     keymap.Binding
+	SelectPreviousColumn keymap.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		SelectNext:           keymap.NewBinding("j", "down").WithHelp("↓/j", "down"),
+		SelectPrevious:       keymap.NewBinding("k", "up").WithHelp("↑/k", "up"),
+		SelectFirst:          keymap.NewBinding("g").WithHelp("g", "first"),
+		SelectLast:           keymap.NewBinding("G").WithHelp("G", "last"),
+		ScrollDown:           keymap.NewBinding("ctrl+d").WithHelp("ctrl+d", "page down"),
+		ScrollUp:             keymap.NewBinding("ctrl+u").WithHelp("ctrl+u", "page up"),
+		SelectNextColumn:     keymap.NewBinding("l", "right").WithHelp("→/l", "next column"),
+		SelectPreviousColumn: keymap.NewBinding("h", "left").WithHelp("←/h", "previous column"),
+	}
+}
+
+func (k KeyMap) ShortHelp() []keymap.Binding {
+	return []keymap.Binding{k.SelectPrevious, k.SelectNext}
+}
+
+func (k KeyMap) FullHelp() [][]keymap.Binding {
+	return [][]keymap.Binding{
+		{k.SelectPrevious, k.SelectNext, k.ScrollUp, k.ScrollDown},
+		{k.SelectFirst, k.SelectLast, k.SelectPreviousColumn, k.SelectNextColumn},
+	}
+}
+
+// SortFilterKeyMap is the key bindings of the [SortFilterState].
+type SortFilterKeyMap struct {
+	KeyMap
+
+	SortNext      keymap.Binding
+	SortPrevious  keymap.Binding
+	Reverse       keymap.Binding
+	Filter        keymap.Binding
+	FilterConfirm keymap.Binding
+	FilterClear   keymap.Binding
+}
+
+func DefaultSortFilterKeyMap() SortFilterKeyMap {
+	return SortFilterKeyMap{
+		KeyMap:        DefaultKeyMap(),
+		SortNext:      keymap.NewBinding("s").WithHelp("s", "sort by next column"),
+		SortPrevious:  keymap.NewBinding("S").WithHelp("S", "sort by previous column"),
+		Reverse:       keymap.NewBinding("r").WithHelp("r", "reverse"),
+		Filter:        keymap.NewBinding("/").WithHelp("/", "filter"),
+		FilterConfirm: keymap.NewBinding("enter").WithHelp("enter", "apply filter"),
+		FilterClear:   keymap.NewBinding("esc").WithHelp("esc", "clear filter"),
+	}
+}
+
+func (k SortFilterKeyMap) ShortHelp() []keymap.Binding {
+	return append(k.KeyMap.ShortHelp(), k.SortNext, k.Filter)
+}
+
+func (k SortFilterKeyMap) FullHelp() [][]keymap.Binding {
+	return append(k.KeyMap.FullHelp(), []keymap.Binding{
+		k.SortNext, k.SortPrevious, k.Reverse, k.Filter, k.FilterConfirm, k.FilterClear,
+	})
+}
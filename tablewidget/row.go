@@ -0,0 +1,123 @@
+package tablewidget
+
+import (
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/textwidget"
+)
+
+var _ bento.Widget = (*Cell)(nil)
+
+// Cell is a single cell of the table row.
+type Cell struct {
+	content textwidget.Text
+	style   bento.Style
+}
+
+func NewCell(content textwidget.Text) Cell {
+	return Cell{
+		content: content,
+		style:   bento.NewStyle(),
+	}
+}
+
+func NewCellStr(s string) Cell {
+	return NewCell(textwidget.NewTextStr(s))
+}
+
+func (c Cell) WithContent(content textwidget.Text) Cell {
+	c.content = content
+	return c
+}
+
+func (c Cell) WithStyle(style bento.Style) Cell {
+	c.style = style
+	return c
+}
+
+// Height returns the number of lines of the cell content.
+func (c Cell) Height() int {
+	return c.content.Height()
+}
+
+// Render implements bento.Widget.
+func (c Cell) Render(area bento.Rect, buffer *bento.Buffer) {
+	buffer.SetStyle(area, c.style)
+	c.content.Render(area, buffer)
+}
+
+// Row is a row of table cells.
+//
+// Its height is the height of the tallest cell unless set with [Row.WithHeight].
+type Row struct {
+	cells        []Cell
+	height       int
+	topMargin    int
+	bottomMargin int
+	style        bento.Style
+}
+
+func NewRow(cells ...Cell) Row {
+	return Row{
+		cells:        cells,
+		height:       0,
+		topMargin:    0,
+		bottomMargin: 0,
+		style:        bento.NewStyle(),
+	}
+}
+
+func NewRowStr(cells ...string) Row {
+	row := NewRow()
+
+	for _, c := range cells {
+		row.cells = append(row.cells, NewCellStr(c))
+	}
+
+	return row
+}
+
+func (r Row) WithCells(cells ...Cell) Row {
+	r.cells = cells
+	return r
+}
+
+// WithHeight sets the fixed height of the row.
+// Zero means the height of the tallest cell.
+func (r Row) WithHeight(height int) Row {
+	r.height = max(0, height)
+	return r
+}
+
+func (r Row) WithTopMargin(margin int) Row {
+	r.topMargin = max(0, margin)
+	return r
+}
+
+func (r Row) WithBottomMargin(margin int) Row {
+	r.bottomMargin = max(0, margin)
+	return r
+}
+
+func (r Row) WithStyle(style bento.Style) Row {
+	r.style = style
+	return r
+}
+
+// Height returns the height of the row without margins.
+func (r Row) Height() int {
+	if r.height > 0 {
+		return r.height
+	}
+
+	height := 1
+
+	for _, c := range r.cells {
+		height = max(height, c.Height())
+	}
+
+	return height
+}
+
+func (r Row) heightWithMargin() int {
+	return r.topMargin + r.Height() + r.bottomMargin
+}
@@ -0,0 +1,339 @@
+package tablewidget
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/keymap"
+	"github.com/metafates/bento/textwidget"
+)
+
+// SortFilterState is the table state which also sorts and filters the rows.
+//
+// The rows are identified by their index in the table given to [SortFilterState.Apply],
+// so the selection stays on the same logical row when the view is re-sorted or filtered:
+//
+//	table = m.state.Apply(table)
+//	table.RenderStateful(area, buffer, &m.state.State)
+type SortFilterState struct {
+	State
+
+	sortColumn     *int
+	sortDescending bool
+
+	filter    string
+	filtering bool
+
+	// columns is the columns count of the last applied table.
+	columns int
+
+	// view maps the rows of the last applied view to the table rows.
+	view []int
+
+	keyMap *SortFilterKeyMap
+}
+
+func NewSortFilterState() SortFilterState {
+	return SortFilterState{
+		State:          NewState(),
+		sortColumn:     nil,
+		sortDescending: false,
+		filter:         "",
+		filtering:      false,
+		columns:        0,
+		view:           nil,
+		keyMap:         nil,
+	}
+}
+
+// KeyMap returns the key bindings of the state, [DefaultSortFilterKeyMap] unless set
+// with [SortFilterState.SetKeyMap].
+func (s *SortFilterState) KeyMap() SortFilterKeyMap {
+	if s.keyMap == nil {
+		return DefaultSortFilterKeyMap()
+	}
+
+	return *s.keyMap
+}
+
+// SetKeyMap sets the key bindings, including the selection ones of the embedded [State].
+func (s *SortFilterState) SetKeyMap(keyMap SortFilterKeyMap) {
+	s.keyMap = &keyMap
+	s.State.SetKeyMap(keyMap.KeyMap)
+}
+
+// TryUpdate handles the selection keys of [State] and the following by default:
+//
+//   - s, S: sort by the next or the previous column, cycling through no sorting
+//   - r: reverse the sort direction
+//   - /: start typing the filter, enter to confirm, esc to clear it
+//
+// See [SortFilterKeyMap].
+func (s *SortFilterState) TryUpdate(msg bento.Msg) (bool, bento.Cmd) {
+	keyMsg, ok := msg.(bento.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+
+	key := bento.Key(keyMsg)
+	keyMap := s.KeyMap()
+
+	if s.filtering {
+		return s.updateFilter(key, keyMap), nil
+	}
+
+	switch {
+	case keymap.Matches(key, keyMap.SortNext):
+		s.cycleSortColumn(1)
+		return true, nil
+
+	case keymap.Matches(key, keyMap.SortPrevious):
+		s.cycleSortColumn(-1)
+		return true, nil
+
+	case keymap.Matches(key, keyMap.Reverse):
+		s.sortDescending = !s.sortDescending
+		return true, nil
+
+	case keymap.Matches(key, keyMap.Filter):
+		s.filtering = true
+		return true, nil
+
+	default:
+		return s.update(key), nil
+	}
+}
+
+func (s *SortFilterState) updateFilter(key bento.Key, keyMap SortFilterKeyMap) bool {
+	switch {
+	case keymap.Matches(key, keyMap.FilterConfirm):
+		s.filtering = false
+
+	case keymap.Matches(key, keyMap.FilterClear):
+		s.filtering = false
+		s.filter = ""
+
+	case key.Type == bento.KeyBackspace:
+		runes := []rune(s.filter)
+		s.filter = string(runes[:max(0, len(runes)-1)])
+
+	case key.Type == bento.KeySpace:
+		s.filter += " "
+
+	case key.Type == bento.KeyRunes:
+		s.filter += string(key.Runes)
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// cycleSortColumn moves the sort column by delta, with no sorting
+// between the last and the first column.
+func (s *SortFilterState) cycleSortColumn(delta int) {
+	if s.columns == 0 {
+		return
+	}
+
+	// -1 stands for no sorting
+	current := -1
+	if s.sortColumn != nil {
+		current = *s.sortColumn
+	}
+
+	next := (current+1+delta+s.columns+1)%(s.columns+1) - 1
+	if next < 0 {
+		s.sortColumn = nil
+		return
+	}
+
+	s.sortColumn = &next
+}
+
+// SortBy sorts the rows by the column.
+func (s *SortFilterState) SortBy(column int, descending bool) {
+	column = max(0, column)
+
+	s.sortColumn = &column
+	s.sortDescending = descending
+}
+
+// Unsort keeps the rows in their original order.
+func (s *SortFilterState) Unsort() {
+	s.sortColumn = nil
+	s.sortDescending = false
+}
+
+// SortColumn returns the column the rows are sorted by.
+// Returns ok = false if the rows are not sorted.
+func (s *SortFilterState) SortColumn() (column int, ok bool) {
+	if s.sortColumn == nil {
+		return 0, false
+	}
+
+	return *s.sortColumn, true
+}
+
+func (s *SortFilterState) SortDescending() bool {
+	return s.sortDescending
+}
+
+// SetFilter sets the filter. Only the rows containing it in any cell are shown, ignoring case.
+func (s *SortFilterState) SetFilter(filter string) {
+	s.filter = filter
+}
+
+func (s *SortFilterState) Filter() string {
+	return s.filter
+}
+
+// Filtering reports whether the filter is being typed.
+func (s *SortFilterState) Filtering() bool {
+	return s.filtering
+}
+
+// RowIndex returns the index of the table row shown at the view index.
+// Returns ok = false if there is no such row.
+func (s *SortFilterState) RowIndex(viewIndex int) (index int, ok bool) {
+	if viewIndex < 0 || viewIndex >= len(s.view) {
+		return 0, false
+	}
+
+	return s.view[viewIndex], true
+}
+
+// SelectedRow returns the index of the selected table row.
+// Unlike [State.Selected], it is the index in the rows of the applied table, not in the view.
+func (s *SortFilterState) SelectedRow() (index int, ok bool) {
+	if len(s.view) == 0 {
+		return 0, false
+	}
+
+	selected, ok := s.Selected(len(s.view) - 1)
+	if !ok {
+		return 0, false
+	}
+
+	return s.view[selected], true
+}
+
+// Apply returns the table showing the sorted and filtered rows.
+// The header cell of the sort column is marked with the sort direction.
+//
+// The selection is moved to the view position of the previously selected row.
+// If the row was filtered out, the selection position is kept.
+func (s *SortFilterState) Apply(table Table) Table {
+	anchor, anchored := s.SelectedRow()
+
+	s.columns = table.columnCount()
+	s.view = s.newView(table.rows)
+
+	if anchored {
+		if i := slices.Index(s.view, anchor); i >= 0 {
+			s.Select(i)
+		}
+	}
+
+	rows := make([]Row, len(s.view))
+	for i, index := range s.view {
+		rows[i] = table.rows[index]
+	}
+
+	table.rows = rows
+
+	if table.header != nil && s.sortColumn != nil && *s.sortColumn < len(table.header.cells) {
+		table = table.WithHeader(s.markHeader(*table.header, *s.sortColumn))
+	}
+
+	return table
+}
+
+func (s *SortFilterState) newView(rows []Row) []int {
+	filter := strings.ToLower(s.filter)
+
+	view := make([]int, 0, len(rows))
+
+	for i, r := range rows {
+		if filter == "" || r.contains(filter) {
+			view = append(view, i)
+		}
+	}
+
+	if s.sortColumn == nil {
+		return view
+	}
+
+	column := *s.sortColumn
+
+	slices.SortStableFunc(view, func(a, b int) int {
+		c := compareCells(rows[a].cellString(column), rows[b].cellString(column))
+
+		if s.sortDescending {
+			return -c
+		}
+
+		return c
+	})
+
+	return view
+}
+
+func (s *SortFilterState) markHeader(header Row, column int) Row {
+	indicator := " ▲"
+	if s.sortDescending {
+		indicator = " ▼"
+	}
+
+	cells := slices.Clone(header.cells)
+
+	content := cells[column].content
+	content.Lines = slices.Clone(content.Lines)
+
+	textwidget.AppendTextSpans(&content, textwidget.NewSpan(indicator))
+
+	cells[column] = cells[column].WithContent(content)
+
+	return header.WithCells(cells...)
+}
+
+// contains reports whether any cell contains the lowercase substring, ignoring case.
+func (r Row) contains(substr string) bool {
+	for _, c := range r.cells {
+		if strings.Contains(strings.ToLower(c.content.String()), substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Row) cellString(column int) string {
+	if column >= len(r.cells) {
+		return ""
+	}
+
+	return r.cells[column].content.String()
+}
+
+// compareCells compares the cells as numbers if both are numbers, otherwise as strings ignoring case.
+func compareCells(a, b string) int {
+	x, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
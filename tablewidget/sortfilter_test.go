@@ -0,0 +1,146 @@
+package tablewidget
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/stretchr/testify/require"
+)
+
+func typeKeys(t *testing.T, state *SortFilterState, keys ...bento.KeyMsg) {
+	t.Helper()
+
+	for _, key := range keys {
+		handled, _ := state.TryUpdate(key)
+		require.True(t, handled, "key %q", key.String())
+	}
+}
+
+func runes(s string) []bento.KeyMsg {
+	var keys []bento.KeyMsg
+
+	for _, r := range s {
+		keys = append(keys, bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune{r}})
+	}
+
+	return keys
+}
+
+func fruitsTable() Table {
+	return New([]Row{
+		NewRowStr("banana", "10"),
+		NewRowStr("apple", "9"),
+		NewRowStr("cherry", "100"),
+		NewRowStr("date", "2"),
+	}, bento.ConstraintLen(6), bento.ConstraintLen(5)).
+		WithHeader(NewRowStr("Name", "Qty")).
+		WithHighlightSymbol(">")
+}
+
+func TestSortFilterState_Sort(t *testing.T) {
+	state := NewSortFilterState()
+	state.Select(0)
+
+	table := fruitsTable()
+
+	buffer := statefulWidget(state.Apply(table), &state.State, 13, 5)
+	bentotest.AssertBufferLines(t, []string{
+		" Name   Qty  ",
+		">banana 10   ",
+		" apple  9    ",
+		" cherry 100  ",
+		" date   2    ",
+	}, buffer)
+
+	// sort by quantity, numerically
+	typeKeys(t, &state, runes("ss")...)
+
+	buffer = statefulWidget(state.Apply(table), &state.State, 13, 5)
+	bentotest.AssertBufferLines(t, []string{
+		" Name   Qty ▲",
+		" date   2    ",
+		" apple  9    ",
+		">banana 10   ",
+		" cherry 100  ",
+	}, buffer)
+
+	typeKeys(t, &state, runes("r")...)
+
+	buffer = statefulWidget(state.Apply(table), &state.State, 13, 5)
+	bentotest.AssertBufferLines(t, []string{
+		" Name   Qty ▼",
+		" cherry 100  ",
+		">banana 10   ",
+		" apple  9    ",
+		" date   2    ",
+	}, buffer)
+
+	row, ok := state.SelectedRow()
+	require.True(t, ok)
+	require.Equal(t, 0, row)
+
+	// cycle back to the original order
+	typeKeys(t, &state, runes("s")...)
+
+	_, ok = state.SortColumn()
+	require.False(t, ok)
+}
+
+func TestSortFilterState_Filter(t *testing.T) {
+	state := NewSortFilterState()
+	state.Select(2)
+
+	table := fruitsTable()
+	state.Apply(table)
+
+	typeKeys(t, &state, runes("/ERj")...)
+	require.True(t, state.Filtering())
+
+	// "j" was typed into the filter, not handled as a selection key
+	typeKeys(t, &state, bento.KeyMsg{Type: bento.KeyBackspace}, bento.KeyMsg{Type: bento.KeyEnter})
+	require.False(t, state.Filtering())
+	require.Equal(t, "ER", state.Filter())
+
+	buffer := statefulWidget(state.Apply(table), &state.State, 13, 5)
+	bentotest.AssertBufferLines(t, []string{
+		" Name   Qty  ",
+		">cherry 100  ",
+		"             ",
+		"             ",
+		"             ",
+	}, buffer)
+
+	typeKeys(t, &state, runes("/")...)
+	typeKeys(t, &state, bento.KeyMsg{Type: bento.KeyEsc})
+
+	buffer = statefulWidget(state.Apply(table), &state.State, 13, 5)
+	bentotest.AssertBufferLines(t, []string{
+		" Name   Qty  ",
+		" banana 10   ",
+		" apple  9    ",
+		">cherry 100  ",
+		" date   2    ",
+	}, buffer)
+}
+
+func TestSortFilterState_FilteredOutSelection(t *testing.T) {
+	state := NewSortFilterState()
+	state.Select(3)
+
+	table := fruitsTable()
+	state.Apply(table)
+
+	state.SetFilter("an")
+
+	buffer := statefulWidget(state.Apply(table), &state.State, 13, 3)
+	bentotest.AssertBufferLines(t, []string{
+		" Name   Qty  ",
+		">banana 10   ",
+		"             ",
+	}, buffer)
+
+	row, ok := state.SelectedRow()
+	require.True(t, ok)
+	require.Equal(t, 0, row)
+}
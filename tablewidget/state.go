@@ -0,0 +1,223 @@
+package tablewidget
+
+import (
+	"math"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/keymap"
+)
+
+type State struct {
+	offset         int
+	selected       *int
+	selectedColumn *int
+	keyMap         *KeyMap
+}
+
+func NewState() State {
+	return State{
+		offset:         0,
+		selected:       nil,
+		selectedColumn: nil,
+		keyMap:         nil,
+	}
+}
+
+// KeyMap returns the key bindings of the state, [DefaultKeyMap] unless set with [State.SetKeyMap].
+func (s *State) KeyMap() KeyMap {
+	if s.keyMap == nil {
+		return DefaultKeyMap()
+	}
+
+	return *s.keyMap
+}
+
+func (s *State) SetKeyMap(keyMap KeyMap) {
+	s.keyMap = &keyMap
+}
+
+func (s *State) TryUpdate(msg bento.Msg) (bool, bento.Cmd) {
+	keyMsg, ok := msg.(bento.KeyMsg)
+	if !ok {
+		return false, nil
+	}
+
+	return s.update(bento.Key(keyMsg)), nil
+}
+
+func (s *State) update(key bento.Key) bool {
+	keyMap := s.KeyMap()
+
+	switch {
+	case keymap.Matches(key, keyMap.ScrollUp):
+		s.ScrollUpBy(8)
+		return true
+
+	case keymap.Matches(key, keyMap.ScrollDown):
+		s.ScrollDownBy(8)
+		return true
+
+	case keymap.Matches(key, keyMap.SelectLast):
+		s.SelectLast()
+		return true
+
+	case keymap.Matches(key, keyMap.SelectFirst):
+		s.SelectFirst()
+		return true
+
+	case keymap.Matches(key, keyMap.SelectNext):
+		s.SelectNext()
+		return true
+
+	case keymap.Matches(key, keyMap.SelectPrevious):
+		s.SelectPrevious()
+		return true
+
+	case keymap.Matches(key, keyMap.SelectNextColumn):
+		s.SelectNextColumn()
+		return true
+
+	case keymap.Matches(key, keyMap.SelectPreviousColumn):
+		s.SelectPreviousColumn()
+		return true
+
+	default:
+		return false
+	}
+}
+
+func (s *State) Offset() int {
+	return s.offset
+}
+
+func (s *State) SetOffset(offset int) {
+	s.offset = offset
+}
+
+// Select selects the row.
+func (s *State) Select(index int) {
+	index = max(0, index)
+	s.selected = &index
+}
+
+// SelectColumn selects the column.
+func (s *State) SelectColumn(index int) {
+	index = max(0, index)
+	s.selectedColumn = &index
+}
+
+// SelectCell selects both the row and the column.
+func (s *State) SelectCell(row, column int) {
+	s.Select(row)
+	s.SelectColumn(column)
+}
+
+func (s *State) SelectNext() {
+	var next int
+
+	if s.selected != nil {
+		next = *s.selected + 1
+	}
+
+	s.Select(next)
+}
+
+func (s *State) SelectPrevious() {
+	previous := math.MaxInt
+
+	if s.selected != nil {
+		previous = max(0, *s.selected-1)
+	}
+
+	s.Select(previous)
+}
+
+func (s *State) SelectFirst() {
+	s.Select(0)
+}
+
+func (s *State) SelectLast() {
+	s.Select(math.MaxInt)
+}
+
+func (s *State) SelectNextColumn() {
+	var next int
+
+	if s.selectedColumn != nil {
+		next = *s.selectedColumn + 1
+	}
+
+	s.SelectColumn(next)
+}
+
+func (s *State) SelectPreviousColumn() {
+	previous := math.MaxInt
+
+	if s.selectedColumn != nil {
+		previous = max(0, *s.selectedColumn-1)
+	}
+
+	s.SelectColumn(previous)
+}
+
+func (s *State) SelectFirstColumn() {
+	s.SelectColumn(0)
+}
+
+func (s *State) SelectLastColumn() {
+	s.SelectColumn(math.MaxInt)
+}
+
+func (s *State) ScrollDownBy(amount int) {
+	var selected int
+
+	if s.selected != nil {
+		selected = *s.selected
+	}
+
+	s.Select(selected + amount)
+}
+
+func (s *State) ScrollUpBy(amount int) {
+	var selected int
+
+	if s.selected != nil {
+		selected = *s.selected
+	}
+
+	s.Select(selected - amount)
+}
+
+// Unselect clears both the row and the column selection.
+func (s *State) Unselect() {
+	s.selected = nil
+	s.selectedColumn = nil
+	s.offset = 0
+}
+
+func (s *State) UnselectColumn() {
+	s.selectedColumn = nil
+}
+
+// Selected returns index of the selected row.
+// Returns ok = false if no row is selected.
+//
+// NOTE: Like [listwidget.State.Selected], the state does not know the rows count.
+// Therefore, you must supply a max value as an argument
+func (s *State) Selected(max_ int) (index int, ok bool) {
+	if s.selected == nil {
+		return 0, false
+	}
+
+	return min(max_, max(0, *s.selected)), true
+}
+
+// SelectedColumn returns index of the selected column.
+// Returns ok = false if no column is selected.
+func (s *State) SelectedColumn(max_ int) (index int, ok bool) {
+	if s.selectedColumn == nil {
+		return 0, false
+	}
+
+	return min(max_, max(0, *s.selectedColumn)), true
+}
@@ -0,0 +1,356 @@
+package tablewidget
+
+import (
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/blockwidget"
+	"github.com/metafates/bento/internal/scroll"
+	"github.com/metafates/bento/listwidget"
+	"github.com/rivo/uniseg"
+)
+
+// HighlightSpacing controls when the space for the highlight symbol is added.
+type HighlightSpacing = listwidget.HighlightSpacing
+
+const (
+	HighlightSpacingWhenSelected = listwidget.HighlightSpacingWhenSelected
+	HighlightSpacingAlways       = listwidget.HighlightSpacingAlways
+	HighlightSpacingNever        = listwidget.HighlightSpacingNever
+)
+
+var (
+	_ bento.StatefulWidget[*State] = (*Table)(nil)
+	_ bento.Widget                 = (*Table)(nil)
+)
+
+// Table is a widget to display rows of cells in columns.
+//
+// Column widths are solved by a horizontal [bento.Layout] of the given constraints.
+type Table struct {
+	rows   []Row
+	header *Row
+	footer *Row
+
+	widths        []bento.Constraint
+	columnSpacing int
+	flex          bento.Flex
+
+	block *blockwidget.Block
+	style bento.Style
+
+	rowHighlightStyle    bento.Style
+	columnHighlightStyle bento.Style
+	cellHighlightStyle   bento.Style
+	highlightSymbol      string
+	highlightSpacing     HighlightSpacing
+	scrollPadding        int
+}
+
+// New returns a new table of rows with the given column widths.
+// If no widths are given, the columns share the width equally.
+func New(rows []Row, widths ...bento.Constraint) Table {
+	return Table{
+		rows:                 rows,
+		header:               nil,
+		footer:               nil,
+		widths:               widths,
+		columnSpacing:        1,
+		flex:                 bento.FlexStart,
+		block:                nil,
+		style:                bento.NewStyle(),
+		rowHighlightStyle:    bento.NewStyle(),
+		columnHighlightStyle: bento.NewStyle(),
+		cellHighlightStyle:   bento.NewStyle(),
+		highlightSymbol:      "",
+		highlightSpacing:     HighlightSpacingWhenSelected,
+		scrollPadding:        0,
+	}
+}
+
+func (t Table) WithRows(rows ...Row) Table {
+	t.rows = rows
+	return t
+}
+
+func (t Table) WithHeader(header Row) Table {
+	t.header = &header
+	return t
+}
+
+func (t Table) WithFooter(footer Row) Table {
+	t.footer = &footer
+	return t
+}
+
+func (t Table) WithWidths(widths ...bento.Constraint) Table {
+	t.widths = widths
+	return t
+}
+
+func (t Table) WithColumnSpacing(spacing int) Table {
+	t.columnSpacing = max(0, spacing)
+	return t
+}
+
+// WithFlex sets how the columns are placed when they do not fill the whole width.
+func (t Table) WithFlex(flex bento.Flex) Table {
+	t.flex = flex
+	return t
+}
+
+func (t Table) WithBlock(block blockwidget.Block) Table {
+	t.block = &block
+	return t
+}
+
+func (t Table) WithStyle(style bento.Style) Table {
+	t.style = style
+	return t
+}
+
+func (t Table) WithRowHighlightStyle(style bento.Style) Table {
+	t.rowHighlightStyle = style
+	return t
+}
+
+func (t Table) WithColumnHighlightStyle(style bento.Style) Table {
+	t.columnHighlightStyle = style
+	return t
+}
+
+// WithCellHighlightStyle sets the style of the cell where the selected row and column intersect.
+func (t Table) WithCellHighlightStyle(style bento.Style) Table {
+	t.cellHighlightStyle = style
+	return t
+}
+
+func (t Table) WithHighlightSymbol(symbol string) Table {
+	t.highlightSymbol = symbol
+	return t
+}
+
+func (t Table) WithHighlightSpacing(highlightSpacing HighlightSpacing) Table {
+	t.highlightSpacing = highlightSpacing
+	return t
+}
+
+func (t Table) WithScrollPadding(padding int) Table {
+	t.scrollPadding = padding
+	return t
+}
+
+// Render implements bento.Widget.
+func (t Table) Render(area bento.Rect, buffer *bento.Buffer) {
+	t.RenderStateful(area, buffer, new(State))
+}
+
+func (t Table) RenderStateful(area bento.Rect, buffer *bento.Buffer, state *State) {
+	buffer.SetStyle(area, t.style)
+
+	tableArea := area
+	if t.block != nil {
+		t.block.Render(area, buffer)
+		tableArea = t.block.Inner(area)
+	}
+
+	if tableArea.IsEmpty() {
+		return
+	}
+
+	if state.selected != nil && *state.selected >= len(t.rows) {
+		state.Select(max(0, len(t.rows)-1))
+	}
+
+	var selectionWidth int
+	if scroll.ShouldAddHighlightSpacing(int(t.highlightSpacing), state.selected != nil) {
+		selectionWidth = uniseg.StringWidth(t.highlightSymbol)
+	}
+
+	columns := t.columnAreas(tableArea, selectionWidth)
+
+	if state.selectedColumn != nil && *state.selectedColumn >= len(columns) {
+		state.SelectColumn(max(0, len(columns)-1))
+	}
+
+	headerArea, rowsArea, footerArea := t.areas(tableArea)
+
+	if t.header != nil {
+		renderRow(headerArea, buffer, *t.header, columns)
+	}
+
+	if t.footer != nil {
+		renderRow(footerArea, buffer, *t.footer, columns)
+	}
+
+	t.renderRows(rowsArea, buffer, state, selectionWidth, columns)
+}
+
+// areas splits the table area into the header, rows and footer areas.
+func (t Table) areas(area bento.Rect) (header, rows, footer bento.Rect) {
+	var headerHeight, footerHeight int
+
+	if t.header != nil {
+		headerHeight = min(area.Height, t.header.heightWithMargin())
+	}
+
+	if t.footer != nil {
+		footerHeight = min(area.Height-headerHeight, t.footer.heightWithMargin())
+	}
+
+	header = bento.Rect{X: area.X, Y: area.Y, Width: area.Width, Height: headerHeight}
+	rows = bento.Rect{X: area.X, Y: area.Y + headerHeight, Width: area.Width, Height: area.Height - headerHeight - footerHeight}
+	footer = bento.Rect{X: area.X, Y: rows.Bottom(), Width: area.Width, Height: footerHeight}
+
+	return header, rows, footer
+}
+
+// columnAreas returns the areas of the columns spanning the whole table height.
+func (t Table) columnAreas(area bento.Rect, selectionWidth int) []bento.Rect {
+	widths := t.widths
+
+	if len(widths) == 0 {
+		count := t.columnCount()
+		if count == 0 {
+			return nil
+		}
+
+		widths = make([]bento.Constraint, count)
+		for i := range widths {
+			widths[i] = bento.ConstraintFill(1)
+		}
+	}
+
+	return bento.NewLayout(widths...).
+		Horizontal().
+		WithFlex(t.flex).
+		WithSpacing(bento.SpacingSpace(t.columnSpacing)).
+		Split(area.IndentX(selectionWidth))
+}
+
+// columnCount returns the largest number of cells in a row, header or footer.
+func (t Table) columnCount() int {
+	var count int
+
+	for _, r := range t.rows {
+		count = max(count, len(r.cells))
+	}
+
+	if t.header != nil {
+		count = max(count, len(t.header.cells))
+	}
+
+	if t.footer != nil {
+		count = max(count, len(t.footer.cells))
+	}
+
+	return count
+}
+
+// renderRow renders the row with its margins in the given area.
+func renderRow(area bento.Rect, buffer *bento.Buffer, row Row, columns []bento.Rect) bento.Rect {
+	rowArea := area.Intersection(bento.Rect{
+		X:      area.X,
+		Y:      area.Y + row.topMargin,
+		Width:  area.Width,
+		Height: row.Height(),
+	})
+
+	buffer.SetStyle(rowArea, row.style)
+
+	for i, cell := range row.cells {
+		if i >= len(columns) {
+			break
+		}
+
+		cell.Render(bento.Rect{
+			X:      columns[i].X,
+			Y:      rowArea.Y,
+			Width:  columns[i].Width,
+			Height: rowArea.Height,
+		}, buffer)
+	}
+
+	return rowArea
+}
+
+func (t Table) renderRows(
+	area bento.Rect,
+	buffer *bento.Buffer,
+	state *State,
+	selectionWidth int,
+	columns []bento.Rect,
+) {
+	if area.IsEmpty() || len(t.rows) == 0 {
+		return
+	}
+
+	firstVisibleIndex, lastVisibleIndex := t.getRowsBounds(state.selected, state.offset, area.Height)
+
+	// NOTE: this changes the state's offset to be the beginning of the now viewable rows
+	state.offset = firstVisibleIndex
+
+	var (
+		currentHeight   int
+		selectedRowArea *bento.Rect
+	)
+
+	for i := firstVisibleIndex; i < lastVisibleIndex; i++ {
+		row := t.rows[i]
+
+		rowArea := renderRow(bento.Rect{
+			X:      area.X,
+			Y:      area.Y + currentHeight,
+			Width:  area.Width,
+			Height: max(0, area.Height-currentHeight),
+		}, buffer, row, columns)
+
+		currentHeight += row.heightWithMargin()
+
+		if state.selected == nil || *state.selected != i {
+			continue
+		}
+
+		if selectionWidth > 0 {
+			buffer.SetStringN(rowArea.X, rowArea.Y, t.highlightSymbol, selectionWidth, row.style)
+		}
+
+		selectedRowArea = &rowArea
+	}
+
+	var selectedColumnArea *bento.Rect
+
+	if state.selectedColumn != nil && *state.selectedColumn < len(columns) {
+		column := columns[*state.selectedColumn]
+
+		selectedColumnArea = &bento.Rect{
+			X:      column.X,
+			Y:      area.Y,
+			Width:  column.Width,
+			Height: area.Height,
+		}
+	}
+
+	if selectedRowArea != nil {
+		buffer.SetStyle(*selectedRowArea, t.rowHighlightStyle)
+	}
+
+	if selectedColumnArea != nil {
+		buffer.SetStyle(*selectedColumnArea, t.columnHighlightStyle)
+	}
+
+	if selectedRowArea != nil && selectedColumnArea != nil {
+		buffer.SetStyle(selectedRowArea.Intersection(*selectedColumnArea), t.cellHighlightStyle)
+	}
+}
+
+func (t Table) rowHeight(index int) int {
+	return t.rows[index].heightWithMargin()
+}
+
+// getRowsBounds given an offset, calculates which rows can fit in a given area.
+func (t Table) getRowsBounds(selected *int, offset, maxHeight int) (int, int) {
+	return scroll.Items{
+		Count:         len(t.rows),
+		Height:        t.rowHeight,
+		ScrollPadding: t.scrollPadding,
+	}.Bounds(selected, offset, maxHeight)
+}
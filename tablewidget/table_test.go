@@ -0,0 +1,151 @@
+package tablewidget
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/stretchr/testify/require"
+)
+
+func statefulWidget(widget Table, state *State, width, height int) bento.Buffer {
+	buffer := bento.NewBufferEmpty(bento.Rect{Width: width, Height: height})
+
+	widget.RenderStateful(buffer.Area(), &buffer, state)
+
+	return buffer
+}
+
+func TestHeaderFooterAndMultilineCells(t *testing.T) {
+	table := New([]Row{
+		NewRowStr("a", "1"),
+		NewRow(NewCellStr("b\nmore"), NewCellStr("2")),
+		NewRowStr("c", "3").WithTopMargin(1),
+	}, bento.ConstraintLen(4), bento.ConstraintLen(3)).
+		WithHeader(NewRowStr("Name", "Val").WithBottomMargin(1)).
+		WithFooter(NewRowStr("Sum", "6"))
+
+	buffer := statefulWidget(table, new(State), 10, 8)
+
+	bentotest.AssertBufferLines(t, []string{
+		"Name Val  ",
+		"          ",
+		"a    1    ",
+		"b    2    ",
+		"more      ",
+		"          ",
+		"c    3    ",
+		"Sum  6    ",
+	}, buffer)
+}
+
+func TestEqualColumnsWithoutWidths(t *testing.T) {
+	table := New([]Row{NewRowStr("a", "b", "c")}).WithColumnSpacing(0)
+
+	buffer := statefulWidget(table, new(State), 6, 1)
+
+	bentotest.AssertBufferLines(t, []string{"a b c "}, buffer)
+}
+
+func TestSelectionScrollsWithPadding(t *testing.T) {
+	rows := []Row{
+		NewRowStr("0", "zero"),
+		NewRowStr("1", "one"),
+		NewRowStr("2", "two"),
+		NewRowStr("3", "three"),
+		NewRowStr("4", "four"),
+		NewRowStr("5", "five"),
+	}
+
+	table := New(rows, bento.ConstraintLen(1), bento.ConstraintFill(1)).
+		WithHeader(NewRowStr("#", "Name")).
+		WithHighlightSymbol("> ").
+		WithScrollPadding(1)
+
+	state := NewState()
+	state.Select(3)
+
+	buffer := statefulWidget(table, &state, 10, 4)
+
+	bentotest.AssertBufferLines(t, []string{
+		"  # Name  ",
+		"  2 two   ",
+		"> 3 three ",
+		"  4 four  ",
+	}, buffer)
+	require.Equal(t, 2, state.Offset())
+
+	state.SelectLast()
+
+	buffer = statefulWidget(table, &state, 10, 4)
+
+	bentotest.AssertBufferLines(t, []string{
+		"  # Name  ",
+		"  3 three ",
+		"  4 four  ",
+		"> 5 five  ",
+	}, buffer)
+
+	selected, ok := state.Selected(len(rows) - 1)
+	require.True(t, ok)
+	require.Equal(t, 5, selected)
+}
+
+func TestRowAndColumnHighlight(t *testing.T) {
+	table := New([]Row{
+		NewRowStr("a", "b"),
+		NewRowStr("c", "d"),
+	}, bento.ConstraintLen(1), bento.ConstraintLen(1)).
+		WithHeader(NewRowStr("x", "y")).
+		WithRowHighlightStyle(bento.NewStyle().Bold()).
+		WithColumnHighlightStyle(bento.NewStyle().Italic()).
+		WithCellHighlightStyle(bento.NewStyle().Reversed())
+
+	state := NewState()
+	state.SelectCell(1, 5)
+
+	buffer := statefulWidget(table, &state, 3, 3)
+
+	column, ok := state.SelectedColumn(1)
+	require.True(t, ok)
+	require.Equal(t, 1, column)
+
+	// header is not highlighted
+	require.Zero(t, buffer.CellAt(bento.Position{X: 2, Y: 0}).Modifier)
+
+	row := buffer.CellAt(bento.Position{X: 0, Y: 2}).Modifier
+	require.NotZero(t, row&bento.ModifierBold)
+	require.Zero(t, row&bento.ModifierItalic)
+
+	col := buffer.CellAt(bento.Position{X: 2, Y: 1}).Modifier
+	require.NotZero(t, col&bento.ModifierItalic)
+	require.Zero(t, col&bento.ModifierBold)
+
+	cell := buffer.CellAt(bento.Position{X: 2, Y: 2}).Modifier
+	require.NotZero(t, cell&bento.ModifierReversed)
+}
+
+func TestStateTryUpdate(t *testing.T) {
+	state := NewState()
+
+	for _, key := range []string{"j", "j", "j", "k", "l", "l", "l", "h"} {
+		handled, _ := state.TryUpdate(bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune(key)})
+		require.True(t, handled)
+	}
+
+	row, ok := state.Selected(10)
+	require.True(t, ok)
+	require.Equal(t, 1, row)
+
+	column, ok := state.SelectedColumn(10)
+	require.True(t, ok)
+	require.Equal(t, 1, column)
+
+	handled, _ := state.TryUpdate(bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune("x")})
+	require.False(t, handled)
+
+	state.Unselect()
+
+	_, ok = state.SelectedColumn(10)
+	require.False(t, ok)
+}
@@ -1,8 +1,11 @@
 package bento
 
 import (
+	"errors"
 	"fmt"
 	"io"
+
+	"github.com/rivo/uniseg"
 )
 
 type PositionedCell struct {
@@ -25,6 +28,11 @@ type Terminal struct {
 	lastKnownCursorPos Position
 
 	hiddenCursor bool
+	cursorShape  CursorShape
+
+	mouseMode           mouseMode
+	reportFocus         bool
+	keyboardEnhancement bool
 
 	frameCount int
 }
@@ -54,7 +62,27 @@ func NewTerminal(backend TerminalBackend, viewport Viewport) (*Terminal, error)
 		viewportArea = area
 		cursorPos = Position{}
 	case ViewportInline:
-		panic("unimplemented")
+		pos, err := backend.GetCursorPosition()
+		if errors.Is(err, ErrInputNotTerminal) {
+			// the cursor position is unknown, start at the bottom of the screen
+			// so that nothing on the screen is drawn over
+			pos, err = Position{Y: max(0, area.Height-1)}, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("get cursor position: %w", err)
+		}
+
+		viewportArea, err = computeInlineSize(backend, int(v), area, pos, 0)
+		if err != nil {
+			return nil, fmt.Errorf("compute inline size: %w", err)
+		}
+
+		cursorPos = viewportArea.Position()
+
+		if err := backend.SetCursorPosition(cursorPos); err != nil {
+			return nil, fmt.Errorf("set cursor position: %w", err)
+		}
 	case ViewportFixed:
 		viewportArea = Rect(v)
 		cursorPos = viewportArea.Position()
@@ -76,6 +104,47 @@ func NewTerminal(backend TerminalBackend, viewport Viewport) (*Terminal, error)
 	}, nil
 }
 
+// computeInlineSize reserves height lines for the inline viewport starting at the given
+// cursor position. If there is not enough room below the cursor, the terminal is scrolled
+// by appending new lines and the viewport is moved up accordingly.
+//
+// offsetInPreviousViewport is the cursor offset from the top of the previous viewport
+// and is used to keep the viewport in place on resize.
+func computeInlineSize(
+	backend TerminalBackend,
+	height int,
+	area Rect,
+	cursorPos Position,
+	offsetInPreviousViewport int,
+) (Rect, error) {
+	row := cursorPos.Y
+
+	maxHeight := min(area.Height, height)
+
+	linesAfterCursor := max(0, height-offsetInPreviousViewport-1)
+
+	if err := backend.SetCursorPosition(cursorPos); err != nil {
+		return Rect{}, fmt.Errorf("set cursor position: %w", err)
+	}
+
+	if err := backend.AppendLines(linesAfterCursor); err != nil {
+		return Rect{}, fmt.Errorf("append lines: %w", err)
+	}
+
+	availableLines := max(0, area.Height-row-1)
+	missingLines := max(0, linesAfterCursor-availableLines)
+
+	row = max(0, row-missingLines)
+	row = max(0, row-offsetInPreviousViewport)
+
+	return Rect{
+		X:      0,
+		Y:      row,
+		Width:  area.Width,
+		Height: maxHeight,
+	}, nil
+}
+
 func (t *Terminal) Viewport() Viewport {
 	return t.viewport
 }
@@ -93,6 +162,22 @@ func (t *Terminal) Read(p []byte) (n int, err error) {
 	return t.backend.Read(p)
 }
 
+// pendingInputBackend is a backend keeping the input read along with a terminal reply,
+// see [DefaultBackend.GetCursorPosition].
+type pendingInputBackend interface {
+	takePendingInput() []byte
+}
+
+// takePendingInput returns the input the backend has read but not returned yet.
+func (t *Terminal) takePendingInput() []byte {
+	backend, ok := t.backend.(pendingInputBackend)
+	if !ok {
+		return nil
+	}
+
+	return backend.takePendingInput()
+}
+
 func (t *Terminal) DisableBracketedPaste() error {
 	return t.backend.DisableBracketedPaste()
 }
@@ -101,6 +186,144 @@ func (t *Terminal) EnableBracketedPaste() error {
 	return t.backend.EnableBracketedPaste()
 }
 
+// EnableMouseCellMotion enables mouse click, release and wheel events.
+// Mouse movement events are also reported while a button is pressed (i.e. drag).
+func (t *Terminal) EnableMouseCellMotion() error {
+	return t.setMouseMode(mouseModeCellMotion)
+}
+
+// EnableMouseAllMotion enables mouse click, release, wheel and movement events,
+// regardless of whether a button is pressed.
+func (t *Terminal) EnableMouseAllMotion() error {
+	return t.setMouseMode(mouseModeAllMotion)
+}
+
+// DisableMouse disables mouse events reporting.
+func (t *Terminal) DisableMouse() error {
+	return t.setMouseMode(mouseModeNone)
+}
+
+// MouseEnabled reports whether mouse events reporting is enabled.
+func (t *Terminal) MouseEnabled() bool {
+	return t.mouseMode != mouseModeNone
+}
+
+func (t *Terminal) setMouseMode(mode mouseMode) error {
+	if t.mouseMode != mouseModeNone {
+		if err := t.backend.DisableMouse(); err != nil {
+			return err
+		}
+
+		t.mouseMode = mouseModeNone
+	}
+
+	switch mode {
+	case mouseModeCellMotion:
+		if err := t.backend.EnableMouseCellMotion(); err != nil {
+			return err
+		}
+	case mouseModeAllMotion:
+		if err := t.backend.EnableMouseAllMotion(); err != nil {
+			return err
+		}
+	}
+
+	t.mouseMode = mode
+	return nil
+}
+
+// EnableReportFocus enables reporting of terminal focus changes.
+func (t *Terminal) EnableReportFocus() error {
+	if err := t.backend.EnableReportFocus(); err != nil {
+		return err
+	}
+
+	t.reportFocus = true
+	return nil
+}
+
+// DisableReportFocus disables reporting of terminal focus changes.
+func (t *Terminal) DisableReportFocus() error {
+	if err := t.backend.DisableReportFocus(); err != nil {
+		return err
+	}
+
+	t.reportFocus = false
+	return nil
+}
+
+// ReportFocusEnabled reports whether focus reporting is enabled.
+func (t *Terminal) ReportFocusEnabled() bool {
+	return t.reportFocus
+}
+
+// PushKeyboardEnhancement enables the kitty keyboard protocol with the given flags.
+// Terminals not supporting it ignore the request and keep sending the legacy key sequences.
+func (t *Terminal) PushKeyboardEnhancement(flags KeyboardEnhancement) error {
+	if err := t.backend.PushKeyboardEnhancement(flags); err != nil {
+		return err
+	}
+
+	t.keyboardEnhancement = true
+	return nil
+}
+
+// PopKeyboardEnhancement restores the keyboard protocol flags to the ones before the push.
+func (t *Terminal) PopKeyboardEnhancement() error {
+	if err := t.backend.PopKeyboardEnhancement(); err != nil {
+		return err
+	}
+
+	t.keyboardEnhancement = false
+	return nil
+}
+
+// KeyboardEnhancementEnabled reports whether the kitty keyboard protocol flags were pushed.
+func (t *Terminal) KeyboardEnhancementEnabled() bool {
+	return t.keyboardEnhancement
+}
+
+// HandleMsg applies the messages that affect the terminal, such as [WindowSizeMsg]
+// or the ones produced by [InsertBefore], [EnableMouseCellMotion] and [EnableReportFocus] commands.
+// Other messages are ignored.
+//
+// It is called by the app for every message before the model update.
+// Custom runners, e.g. in tests, must call it too.
+func (t *Terminal) HandleMsg(msg Msg) error {
+	switch msg := msg.(type) {
+	case WindowSizeMsg:
+		if err := t.Resize(NewRect(msg.Width, msg.Height)); err != nil {
+			return fmt.Errorf("resize: %w", err)
+		}
+	case enableMouseCellMotionMsg:
+		if err := t.EnableMouseCellMotion(); err != nil {
+			return fmt.Errorf("enable mouse cell motion: %w", err)
+		}
+	case enableMouseAllMotionMsg:
+		if err := t.EnableMouseAllMotion(); err != nil {
+			return fmt.Errorf("enable mouse all motion: %w", err)
+		}
+	case disableMouseMsg:
+		if err := t.DisableMouse(); err != nil {
+			return fmt.Errorf("disable mouse: %w", err)
+		}
+	case enableReportFocusMsg:
+		if err := t.EnableReportFocus(); err != nil {
+			return fmt.Errorf("enable report focus: %w", err)
+		}
+	case disableReportFocusMsg:
+		if err := t.DisableReportFocus(); err != nil {
+			return fmt.Errorf("disable report focus: %w", err)
+		}
+	case insertBeforeMsg:
+		if err := t.InsertBefore(msg.height, msg.draw); err != nil {
+			return fmt.Errorf("insert before: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (t *Terminal) EnableAlternateScreen() error {
 	return t.backend.EnableAlternateScreen()
 }
@@ -126,17 +349,21 @@ func (t *Terminal) Draw(widget Widget) (CompletedFrame, error) {
 		return CompletedFrame{}, fmt.Errorf("flush: %w", err)
 	}
 
-	if frame.cursorPosition == nil {
-		if err := t.HideCursor(); err != nil {
-			return CompletedFrame{}, fmt.Errorf("hide cursor: %w", err)
+	if cursorPosition, ok := frame.buffer.CursorPosition(); ok {
+		if err := t.SetCursorShape(frame.buffer.CursorShape()); err != nil {
+			return CompletedFrame{}, fmt.Errorf("set cursor shape: %w", err)
 		}
-	} else {
+
+		if err := t.SetCursorPosition(cursorPosition); err != nil {
+			return CompletedFrame{}, fmt.Errorf("set cursor position: %w", err)
+		}
+
 		if err := t.ShowCursor(); err != nil {
 			return CompletedFrame{}, fmt.Errorf("show cursor: %w", err)
 		}
-
-		if err := t.SetCursorPosition(*frame.cursorPosition); err != nil {
-			return CompletedFrame{}, fmt.Errorf("set cursor position: %w", err)
+	} else {
+		if err := t.HideCursor(); err != nil {
+			return CompletedFrame{}, fmt.Errorf("hide cursor: %w", err)
 		}
 	}
 
@@ -166,6 +393,21 @@ func (t *Terminal) SetCursorPosition(position Position) error {
 	return nil
 }
 
+// SetCursorShape sets the shape of the cursor.
+// Nothing is written to the backend if the shape has not changed.
+func (t *Terminal) SetCursorShape(shape CursorShape) error {
+	if shape == t.cursorShape {
+		return nil
+	}
+
+	if err := t.backend.SetCursorShape(shape); err != nil {
+		return err
+	}
+
+	t.cursorShape = shape
+	return nil
+}
+
 func (t *Terminal) HideCursor() error {
 	if err := t.backend.HideCursor(); err != nil {
 		return err
@@ -207,10 +449,9 @@ func (t *Terminal) Flush() error {
 
 func (t *Terminal) GetFrame() Frame {
 	return Frame{
-		cursorPosition: nil,
-		viewportArea:   t.viewportArea,
-		buffer:         t.CurrentBuffer(),
-		count:          t.frameCount,
+		viewportArea: t.viewportArea,
+		buffer:       t.CurrentBuffer(),
+		count:        t.frameCount,
 	}
 }
 
@@ -225,9 +466,25 @@ func (t *Terminal) PreviousBuffer() *Buffer {
 func (t *Terminal) Resize(area Rect) error {
 	var nextArea Rect
 
-	switch t.viewport.(type) {
+	switch v := t.viewport.(type) {
 	case ViewportInline:
-		panic("unimplemented")
+		offsetInPreviousViewport := max(0, t.lastKnownCursorPos.Y-t.viewportArea.Top())
+
+		// The real cursor position can't be queried here, since the input is already
+		// being read and the reply would be taken for key presses. The last known one is
+		// exact as long as the output goes through the Terminal: Flush, Clear and
+		// SetCursorPosition keep it up to date and InsertBefore prints above the viewport.
+		cursorPos := Position{
+			X: 0,
+			Y: max(0, min(t.lastKnownCursorPos.Y, area.Height-1)),
+		}
+
+		var err error
+
+		nextArea, err = computeInlineSize(t.backend, int(v), area, cursorPos, offsetInPreviousViewport)
+		if err != nil {
+			return fmt.Errorf("compute inline size: %w", err)
+		}
 	case ViewportFullscreen, ViewportFixed:
 		nextArea = area
 	}
@@ -250,7 +507,13 @@ func (t *Terminal) Clear() error {
 			return fmt.Errorf("clear all: %w", err)
 		}
 	case ViewportInline:
-		if err := t.backend.SetCursorPosition(t.viewportArea.Position()); err != nil {
+		// an empty viewport may be just below the screen, e.g. after InsertBefore,
+		// and clearing there would clear the last line instead
+		if t.viewportArea.IsEmpty() {
+			break
+		}
+
+		if err := t.SetCursorPosition(t.viewportArea.Position()); err != nil {
 			return fmt.Errorf("set cursor position: %w", err)
 		}
 
@@ -276,6 +539,210 @@ func (t *Terminal) Clear() error {
 	return nil
 }
 
+// InsertBefore inserts some content before the current inline viewport.
+// This has no effect when the viewport is not inline.
+//
+// The draw function is called with a buffer of the given height and the viewport width.
+// Its content is printed above the viewport, scrolling the terminal if needed,
+// so that it stays in the scrollback and is not affected by the following frames.
+//
+// The viewport is cleared afterwards and must be redrawn.
+func (t *Terminal) InsertBefore(height int, draw func(buffer *Buffer)) error {
+	if !isInline(t.viewport) {
+		return nil
+	}
+
+	height = max(0, height)
+
+	buffer := NewBufferEmpty(NewRect(t.viewportArea.Width, height))
+	draw(&buffer)
+
+	cells := buffer.content
+
+	drawnHeight := t.viewportArea.Top()
+	bufferHeight := height
+	viewportHeight := t.viewportArea.Height
+	screenHeight := t.lastKnownArea.Height
+
+	// Draw the buffer in chunks of at most a screen height until the rest of
+	// it and the viewport fit on the screen. Scroll as little as possible
+	// to keep the viewport at the bottom of the screen.
+	for bufferHeight+viewportHeight > screenHeight {
+		toDraw := min(bufferHeight, screenHeight)
+		scrollUp := max(0, drawnHeight+toDraw-screenHeight)
+
+		if err := t.scrollUp(scrollUp); err != nil {
+			return fmt.Errorf("scroll up: %w", err)
+		}
+
+		var err error
+
+		cells, err = t.drawLines(drawnHeight-scrollUp, toDraw, cells)
+		if err != nil {
+			return fmt.Errorf("draw lines: %w", err)
+		}
+
+		drawnHeight += toDraw - scrollUp
+		bufferHeight -= toDraw
+	}
+
+	// Now there is enough room on the screen for the rest of the buffer and the viewport,
+	// though some of the existing text may still need to be scrolled up.
+	scrollUp := max(0, drawnHeight+bufferHeight+viewportHeight-screenHeight)
+
+	if err := t.scrollUp(scrollUp); err != nil {
+		return fmt.Errorf("scroll up: %w", err)
+	}
+
+	if _, err := t.drawLines(drawnHeight-scrollUp, bufferHeight, cells); err != nil {
+		return fmt.Errorf("draw lines: %w", err)
+	}
+
+	drawnHeight += bufferHeight - scrollUp
+
+	area := t.viewportArea
+	area.Y = drawnHeight
+
+	t.setViewportArea(area)
+
+	// The viewport is cleared only now, since the drawn lines have already
+	// overwritten whatever was on the screen.
+	if err := t.Clear(); err != nil {
+		return fmt.Errorf("clear: %w", err)
+	}
+
+	return nil
+}
+
+// drawLines draws the given amount of lines from cells starting at the row y.
+// It returns the remaining cells.
+func (t *Terminal) drawLines(y, lines int, cells []Cell) ([]Cell, error) {
+	width := t.viewportArea.Width
+
+	toDraw, remainder := cells[:width*lines], cells[width*lines:]
+
+	if lines == 0 {
+		return remainder, nil
+	}
+
+	updates := make([]PositionedCell, 0, len(toDraw))
+
+	var toSkip int
+
+	for i, cell := range toDraw {
+		if i%width == 0 {
+			toSkip = 0
+		}
+
+		// skip the cells hidden by the preceding wide symbol
+		if toSkip > 0 {
+			toSkip--
+			continue
+		}
+
+		toSkip = max(0, uniseg.StringWidth(cell.Symbol)-1)
+
+		updates = append(updates, PositionedCell{
+			Cell:     cell,
+			Position: Position{X: i % width, Y: y + i/width},
+		})
+	}
+
+	if err := t.backend.Draw(updates); err != nil {
+		return nil, fmt.Errorf("draw: %w", err)
+	}
+
+	if err := t.backend.Flush(); err != nil {
+		return nil, fmt.Errorf("flush: %w", err)
+	}
+
+	return remainder, nil
+}
+
+// scrollUp scrolls the screen up by the given amount of lines
+// by moving the cursor to the bottom of the screen and appending lines.
+func (t *Terminal) scrollUp(lines int) error {
+	if lines <= 0 {
+		return nil
+	}
+
+	if err := t.SetCursorPosition(Position{X: 0, Y: max(0, t.lastKnownArea.Height-1)}); err != nil {
+		return fmt.Errorf("set cursor position: %w", err)
+	}
+
+	if err := t.backend.AppendLines(lines); err != nil {
+		return fmt.Errorf("append lines: %w", err)
+	}
+
+	return nil
+}
+
+// InsertNewLineAfterViewport moves the cursor to the line after the viewport,
+// scrolling the terminal if needed.
+//
+// This is used when leaving an inline viewport so that the last frame
+// stays in the scrollback and the shell prompt is printed below it.
+func (t *Terminal) InsertNewLineAfterViewport() error {
+	if err := t.SetCursorPosition(Position{X: 0, Y: max(0, t.viewportArea.Bottom()-1)}); err != nil {
+		return fmt.Errorf("set cursor position: %w", err)
+	}
+
+	if err := t.backend.AppendLines(1); err != nil {
+		return fmt.Errorf("append lines: %w", err)
+	}
+
+	return nil
+}
+
+// ResetViewport places the inline viewport below the cursor again,
+// e.g. after another process printed to the terminal. The next frame is drawn from scratch.
+// This has no effect when the viewport is not inline.
+//
+// The cursor position is queried like in [NewTerminal],
+// so it must not be called while something else is reading the input.
+func (t *Terminal) ResetViewport() error {
+	v, ok := t.viewport.(ViewportInline)
+	if !ok {
+		return nil
+	}
+
+	area := t.lastKnownArea
+
+	size, ok, err := t.backend.GetSize()
+	if err != nil {
+		return fmt.Errorf("get size: %w", err)
+	}
+
+	if ok {
+		area = NewRect(size.Width, size.Height)
+	}
+
+	pos, err := t.backend.GetCursorPosition()
+	if errors.Is(err, ErrInputNotTerminal) {
+		pos, err = t.lastKnownCursorPos, nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get cursor position: %w", err)
+	}
+
+	nextArea, err := computeInlineSize(t.backend, int(v), area, pos, 0)
+	if err != nil {
+		return fmt.Errorf("compute inline size: %w", err)
+	}
+
+	t.setViewportArea(nextArea)
+	t.lastKnownArea = area
+
+	if err := t.SetCursorPosition(nextArea.Position()); err != nil {
+		return fmt.Errorf("set cursor position: %w", err)
+	}
+
+	t.PreviousBuffer().Reset()
+
+	return nil
+}
+
 func (t *Terminal) SwapBuffers() {
 	t.PreviousBuffer().Reset()
 	t.current = 1 - t.current
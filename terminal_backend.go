@@ -2,15 +2,28 @@ package bento
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/charmbracelet/x/term"
 	"github.com/metafates/bento/internal/ansi"
 	"github.com/metafates/bento/internal/bit"
+	"github.com/muesli/cancelreader"
 	"github.com/muesli/termenv"
 )
 
+// cursorPositionTimeout is how long [DefaultBackend.GetCursorPosition] waits
+// for the terminal to report the cursor position.
+const cursorPositionTimeout = 2 * time.Second
+
+var ErrCursorPositionTimeout = errors.New("cursor position timeout")
+
+// ErrInputNotTerminal is returned when the terminal is queried
+// but there is no input or it is not a terminal, so the reply could not be read.
+var ErrInputNotTerminal = errors.New("input is not a terminal")
+
 type TerminalBackend interface {
 	io.Reader
 
@@ -19,7 +32,9 @@ type TerminalBackend interface {
 	ShowCursor() error
 	GetCursorPosition() (Position, error)
 	SetCursorPosition(position Position) error
+	SetCursorShape(shape CursorShape) error
 	GetSize() (Size, bool, error)
+	AppendLines(n int) error
 	Flush() error
 
 	ClearAll() error
@@ -37,6 +52,16 @@ type TerminalBackend interface {
 	EnableBracketedPaste() error
 	DisableBracketedPaste() error
 
+	EnableMouseCellMotion() error
+	EnableMouseAllMotion() error
+	DisableMouse() error
+
+	EnableReportFocus() error
+	DisableReportFocus() error
+
+	PushKeyboardEnhancement(flags KeyboardEnhancement) error
+	PopKeyboardEnhancement() error
+
 	Input() io.Reader
 	Output() io.Writer
 }
@@ -49,6 +74,10 @@ type DefaultBackend struct {
 	output       io.Writer
 	outputBuf    *bufio.Writer
 
+	// pending is the input read along with a terminal reply.
+	// It is returned by [DefaultBackend.Read] before reading the input again.
+	pending []byte
+
 	prevInputState, prevOutputState ansi.State
 }
 
@@ -61,6 +90,15 @@ func NewDefaultBackend(input io.Reader, output io.Writer) DefaultBackend {
 	}
 }
 
+// WithColorProfile sets the color profile the cell colors are converted to.
+// By default it is detected from the output and the process environment,
+// which is wrong when the output is not the local terminal, e.g. an SSH session.
+func (d DefaultBackend) WithColorProfile(profile termenv.Profile) DefaultBackend {
+	d.colorProfile = profile
+
+	return d
+}
+
 func (d *DefaultBackend) EnableBracketedPaste() error {
 	return d.execute(ansi.EnableBracketedPaste{})
 }
@@ -69,6 +107,45 @@ func (d *DefaultBackend) DisableBracketedPaste() error {
 	return d.execute(ansi.DisableBracketedPaste{})
 }
 
+// EnableMouseCellMotion implements TerminalBackend.
+func (d *DefaultBackend) EnableMouseCellMotion() error {
+	return d.execute(ansi.EnableMouseCellMotion{}, ansi.EnableMouseSGRMode{})
+}
+
+// EnableMouseAllMotion implements TerminalBackend.
+func (d *DefaultBackend) EnableMouseAllMotion() error {
+	return d.execute(ansi.EnableMouseAllMotion{}, ansi.EnableMouseSGRMode{})
+}
+
+// DisableMouse implements TerminalBackend.
+func (d *DefaultBackend) DisableMouse() error {
+	return d.execute(
+		ansi.DisableMouseCellMotion{},
+		ansi.DisableMouseAllMotion{},
+		ansi.DisableMouseSGRMode{},
+	)
+}
+
+// EnableReportFocus implements TerminalBackend.
+func (d *DefaultBackend) EnableReportFocus() error {
+	return d.execute(ansi.EnableReportFocus{})
+}
+
+// DisableReportFocus implements TerminalBackend.
+func (d *DefaultBackend) DisableReportFocus() error {
+	return d.execute(ansi.DisableReportFocus{})
+}
+
+// PushKeyboardEnhancement implements TerminalBackend.
+func (d *DefaultBackend) PushKeyboardEnhancement(flags KeyboardEnhancement) error {
+	return d.execute(ansi.PushKeyboardEnhancement{Flags: int(flags)})
+}
+
+// PopKeyboardEnhancement implements TerminalBackend.
+func (d *DefaultBackend) PopKeyboardEnhancement() error {
+	return d.execute(ansi.PopKeyboardEnhancement{})
+}
+
 func (d *DefaultBackend) Output() io.Writer {
 	return d.output
 }
@@ -79,9 +156,23 @@ func (d *DefaultBackend) Input() io.Reader {
 
 // Read implements TerminalBackend.
 func (d *DefaultBackend) Read(p []byte) (n int, err error) {
+	if len(d.pending) > 0 {
+		n = copy(p, d.pending)
+		d.pending = d.pending[n:]
+
+		return n, nil
+	}
+
 	return d.input.Read(p)
 }
 
+func (d *DefaultBackend) takePendingInput() []byte {
+	pending := d.pending
+	d.pending = nil
+
+	return pending
+}
+
 func (d *DefaultBackend) DisableRawMode() error {
 	if d.input != nil && d.prevInputState != nil {
 		fd, ok := d.inputFd()
@@ -90,6 +181,8 @@ func (d *DefaultBackend) DisableRawMode() error {
 				return fmt.Errorf("restore input: %w", err)
 			}
 		}
+
+		d.prevInputState = nil
 	}
 
 	if d.output != nil && d.prevOutputState != nil {
@@ -99,6 +192,8 @@ func (d *DefaultBackend) DisableRawMode() error {
 				return fmt.Errorf("restore output: %w", err)
 			}
 		}
+
+		d.prevOutputState = nil
 	}
 
 	return nil
@@ -204,17 +299,91 @@ func (d *DefaultBackend) Flush() error {
 	return d.outputBuf.Flush()
 }
 
+// AppendLines implements TerminalBackend.
+func (d *DefaultBackend) AppendLines(n int) error {
+	return d.execute(ansi.AppendLines(n))
+}
+
 // GetCursorPosition implements TerminalBackend.
+//
+// The terminal is asked to report the cursor position and the reply is read from the input.
+// Raw mode is enabled for the duration of the query unless it is already enabled,
+// otherwise the reply would be echoed and line buffered.
+// Other input read along with the reply, e.g. keys pressed meanwhile, is kept for [DefaultBackend.Read].
+//
+// It must not be called while something else is reading the input.
 func (d *DefaultBackend) GetCursorPosition() (Position, error) {
-	column, row, err := ansi.GetCursorPosition()
+	fd, ok := d.inputFd()
+	if !ok || !term.IsTerminal(fd) {
+		return Position{}, ErrInputNotTerminal
+	}
+
+	if d.prevInputState == nil {
+		if err := d.EnableRawMode(); err != nil {
+			return Position{}, fmt.Errorf("enable raw mode: %w", err)
+		}
+
+		defer d.DisableRawMode()
+	}
+
+	reader, err := cancelreader.NewReader(d.input)
 	if err != nil {
-		return Position{}, fmt.Errorf("get cursor position: %w", err)
+		return Position{}, fmt.Errorf("new reader: %w", err)
+	}
+	defer reader.Close()
+
+	if err := d.execute(ansi.RequestCursorPosition{}); err != nil {
+		return Position{}, fmt.Errorf("request cursor position: %w", err)
 	}
 
-	return Position{
-		X: column,
-		Y: row,
-	}, nil
+	type result struct {
+		position Position
+		rest     []byte
+		err      error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		var (
+			buf   [32]byte
+			reply []byte
+		)
+
+		for {
+			n, err := reader.Read(buf[:])
+			if err != nil {
+				done <- result{rest: reply, err: err}
+				return
+			}
+
+			reply = append(reply, buf[:n]...)
+
+			if column, row, rest, ok := ansi.ParseCursorPosition(reply); ok {
+				done <- result{position: Position{X: column, Y: row}, rest: rest}
+				return
+			}
+		}
+	}()
+
+	select {
+	case r := <-done:
+		d.pending = append(d.pending, r.rest...)
+
+		if r.err != nil {
+			return Position{}, fmt.Errorf("read cursor position: %w", r.err)
+		}
+
+		return r.position, nil
+	case <-time.After(cursorPositionTimeout):
+		if reader.Cancel() {
+			// keep what was read before the reader stopped
+			r := <-done
+			d.pending = append(d.pending, r.rest...)
+		}
+
+		return Position{}, ErrCursorPositionTimeout
+	}
 }
 
 // GetSize implements TerminalBackend.
@@ -258,6 +427,11 @@ func (d *DefaultBackend) SetCursorPosition(position Position) error {
 	})
 }
 
+// SetCursorShape implements TerminalBackend.
+func (d *DefaultBackend) SetCursorShape(shape CursorShape) error {
+	return d.execute(ansi.SetCursorShape(shape))
+}
+
 // ShowCursor implements TerminalBackend.
 func (d *DefaultBackend) ShowCursor() error {
 	return d.execute(ansi.ShowCursor{})
@@ -7,6 +7,11 @@ import (
 )
 
 func (d *DefaultBackend) EnableRawMode() error {
+	if d.prevInputState != nil {
+		// already enabled
+		return nil
+	}
+
 	if f, ok := d.input.(term.File); ok && term.IsTerminal(f.Fd()) {
 		state, err := term.MakeRaw(f.Fd())
 		if err != nil {
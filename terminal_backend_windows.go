@@ -10,6 +10,11 @@ import (
 )
 
 func (d *DefaultBackend) EnableRawMode() error {
+	if d.prevInputState != nil {
+		// already enabled
+		return nil
+	}
+
 	// Save stdin state and enable VT input
 	// We also need to enable VT
 	// input here.
@@ -0,0 +1,357 @@
+package bento_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeInlineSize(t *testing.T) {
+	testCases := []struct {
+		name             string
+		height           int
+		cursorY          int
+		offset           int
+		want             bento.Rect
+		wantScrolledRows int
+	}{
+		{
+			name:    "fits below the cursor",
+			height:  2,
+			cursorY: 1,
+			want:    bento.Rect{X: 0, Y: 1, Width: 10, Height: 2},
+		},
+		{
+			name:    "fits exactly",
+			height:  2,
+			cursorY: 3,
+			want:    bento.Rect{X: 0, Y: 3, Width: 10, Height: 2},
+		},
+		{
+			name:             "overflows the bottom",
+			height:           3,
+			cursorY:          3,
+			want:             bento.Rect{X: 0, Y: 2, Width: 10, Height: 3},
+			wantScrolledRows: 1,
+		},
+		{
+			name:             "cursor at the bottom",
+			height:           3,
+			cursorY:          4,
+			want:             bento.Rect{X: 0, Y: 2, Width: 10, Height: 3},
+			wantScrolledRows: 2,
+		},
+		{
+			name:             "taller than the screen",
+			height:           8,
+			cursorY:          2,
+			want:             bento.Rect{X: 0, Y: 0, Width: 10, Height: 5},
+			wantScrolledRows: 5,
+		},
+		{
+			name:    "offset in previous viewport",
+			height:  3,
+			cursorY: 3,
+			offset:  1,
+			want:    bento.Rect{X: 0, Y: 2, Width: 10, Height: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := bentotest.NewBackend(10, 5)
+
+			area, err := bento.ComputeInlineSize(
+				backend,
+				tc.height,
+				bento.NewRect(10, 5),
+				bento.Position{X: 0, Y: tc.cursorY},
+				tc.offset,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, area)
+
+			scrollback := backend.Scrollback()
+			require.Equal(t, tc.wantScrolledRows, scrollback.Area().Height)
+		})
+	}
+}
+
+func TestTerminal_InlineResize(t *testing.T) {
+	backend := bentotest.NewBackend(10, 5)
+
+	require.NoError(t, backend.SetCursorPosition(bento.Position{X: 0, Y: 1}))
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportInline(2))
+	require.NoError(t, err)
+	require.Equal(t, bento.Rect{X: 0, Y: 1, Width: 10, Height: 2}, viewportArea(terminal))
+
+	_, err = terminal.Draw(widgetFunc(func(area bento.Rect, buffer *bento.Buffer) {
+		buffer.SetString(area.X, area.Bottom()-1, "bottom", bento.NewStyle())
+	}))
+	require.NoError(t, err)
+
+	// the viewport stays in place
+	require.NoError(t, terminal.Resize(bento.NewRect(8, 5)))
+	require.Equal(t, bento.Rect{X: 0, Y: 1, Width: 8, Height: 2}, viewportArea(terminal))
+
+	// the viewport moves up to fit
+	require.NoError(t, terminal.Resize(bento.NewRect(8, 2)))
+	require.Equal(t, bento.Rect{X: 0, Y: 0, Width: 8, Height: 2}, viewportArea(terminal))
+}
+
+func TestTerminal_InsertNewLineAfterViewport(t *testing.T) {
+	backend := bentotest.NewBackend(10, 3)
+
+	require.NoError(t, backend.SetCursorPosition(bento.Position{X: 0, Y: 1}))
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportInline(2))
+	require.NoError(t, err)
+
+	_, err = terminal.Draw(widgetFunc(func(area bento.Rect, buffer *bento.Buffer) {
+		buffer.SetString(area.X, area.Y, "frame", bento.NewStyle())
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, terminal.InsertNewLineAfterViewport())
+
+	// the last frame is kept and the prompt goes below it
+	backend.AssertCursorPosition(t, bento.Position{X: 0, Y: 2})
+	backend.AssertScrollbackLines(t, "          ")
+	backend.AssertBufferLines(t,
+		"frame     ",
+		"          ",
+		"          ",
+	)
+}
+
+func viewportArea(terminal *bento.Terminal) bento.Rect {
+	frame := terminal.GetFrame()
+
+	return frame.Area()
+}
+
+type widgetFunc func(area bento.Rect, buffer *bento.Buffer)
+
+func (f widgetFunc) Render(area bento.Rect, buffer *bento.Buffer) {
+	f(area, buffer)
+}
+
+func TestTerminal_InsertBefore(t *testing.T) {
+	testCases := []struct {
+		name           string
+		viewport       int
+		lines          []string
+		wantScrollback []string
+		wantBuffer     []string
+	}{
+		{
+			name:     "taller than the viewport",
+			viewport: 2,
+			lines:    []string{"a", "b", "c"},
+			wantScrollback: []string{
+				"          ",
+				"          ",
+				"a         ",
+			},
+			wantBuffer: []string{
+				"b         ",
+				"c         ",
+				"viewport  ",
+				"          ",
+			},
+		},
+		{
+			name:     "taller than the screen",
+			viewport: 2,
+			lines:    []string{"a", "b", "c", "d", "e", "f"},
+			wantScrollback: []string{
+				"          ",
+				"          ",
+				"a         ",
+				"b         ",
+				"c         ",
+				"d         ",
+			},
+			wantBuffer: []string{
+				"e         ",
+				"f         ",
+				"viewport  ",
+				"          ",
+			},
+		},
+		{
+			name:     "zero height viewport",
+			viewport: 0,
+			lines:    []string{"a", "b", "c"},
+			wantScrollback: []string{
+				"          ",
+			},
+			wantBuffer: []string{
+				"          ",
+				"a         ",
+				"b         ",
+				"c         ",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := bentotest.NewBackend(10, 4)
+
+			require.NoError(t, backend.SetCursorPosition(bento.Position{X: 0, Y: 2}))
+
+			terminal, err := bento.NewTerminal(backend, bento.ViewportInline(tc.viewport))
+			require.NoError(t, err)
+
+			viewport := widgetFunc(func(area bento.Rect, buffer *bento.Buffer) {
+				if area.IsEmpty() {
+					return
+				}
+
+				buffer.SetStringN(area.X, area.Y, "viewport", area.Width, bento.NewStyle())
+			})
+
+			_, err = terminal.Draw(viewport)
+			require.NoError(t, err)
+
+			err = terminal.InsertBefore(len(tc.lines), func(buffer *bento.Buffer) {
+				for i, line := range tc.lines {
+					buffer.SetString(0, i, line, bento.NewStyle())
+				}
+			})
+			require.NoError(t, err)
+
+			_, err = terminal.Draw(viewport)
+			require.NoError(t, err)
+
+			backend.AssertScrollbackLines(t, tc.wantScrollback...)
+			backend.AssertBufferLines(t, tc.wantBuffer...)
+		})
+	}
+}
+
+func TestTerminal_DrawCursor(t *testing.T) {
+	backend := bentotest.NewBackend(10, 2)
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportFullscreen{})
+	require.NoError(t, err)
+
+	_, err = terminal.Draw(widgetFunc(func(area bento.Rect, buffer *bento.Buffer) {
+		buffer.SetCursorPosition(bento.Position{X: 3, Y: 1})
+		buffer.SetCursorShape(bento.CursorShapeBlinkingUnderline)
+	}))
+	require.NoError(t, err)
+
+	backend.AssertCursorPosition(t, bento.Position{X: 3, Y: 1})
+	require.True(t, backend.CursorVisible())
+	require.Equal(t, bento.CursorShapeBlinkingUnderline, backend.CursorShape())
+
+	// the cursor is hidden once a frame does not place it
+	_, err = terminal.Draw(widgetFunc(func(bento.Rect, *bento.Buffer) {}))
+	require.NoError(t, err)
+
+	require.False(t, backend.CursorVisible())
+}
+
+func TestTerminal_DrawCursorOutput(t *testing.T) {
+	var output bytes.Buffer
+
+	backend := bento.NewDefaultBackend(nil, &output)
+
+	terminal, err := bento.NewTerminal(&backend, bento.ViewportFixed(bento.NewRect(10, 2)))
+	require.NoError(t, err)
+
+	_, err = terminal.Draw(widgetFunc(func(area bento.Rect, buffer *bento.Buffer) {
+		buffer.SetCursorPosition(bento.Position{X: 3, Y: 1})
+		buffer.SetCursorShape(bento.CursorShapeSteadyBar)
+	}))
+	require.NoError(t, err)
+
+	requireInOrder(t, output.String(), "\x1b[6 q", "\x1b[2;4H", "\x1b[?25h")
+
+	output.Reset()
+
+	_, err = terminal.Draw(widgetFunc(func(bento.Rect, *bento.Buffer) {}))
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "\x1b[?25l")
+	require.NotContains(t, output.String(), "\x1b[?25h")
+
+	require.NoError(t, terminal.SetCursorShape(bento.CursorShapeDefault))
+	require.Contains(t, output.String(), "\x1b[0 q")
+}
+
+func TestDefaultBackend_GetCursorPositionWithoutTerminal(t *testing.T) {
+	var output bytes.Buffer
+
+	backend := bento.NewDefaultBackend(strings.NewReader("keys"), &output)
+
+	_, err := backend.GetCursorPosition()
+	require.ErrorIs(t, err, bento.ErrInputNotTerminal)
+
+	// the query is not sent and the input is left for the app
+	require.NoError(t, backend.Flush())
+	require.Empty(t, output.String())
+
+	input, err := io.ReadAll(&backend)
+	require.NoError(t, err)
+	require.Equal(t, "keys", string(input))
+
+	// an inline viewport still starts
+	terminal, err := bento.NewTerminal(&backend, bento.ViewportInline(2))
+	require.NoError(t, err)
+	require.Equal(t, bento.Rect{}, viewportArea(terminal))
+}
+
+func TestTerminal_ResetViewport(t *testing.T) {
+	backend := bentotest.NewBackend(10, 5)
+
+	require.NoError(t, backend.SetCursorPosition(bento.Position{X: 0, Y: 1}))
+
+	terminal, err := bento.NewTerminal(backend, bento.ViewportInline(2))
+	require.NoError(t, err)
+
+	draw := func(text string) {
+		_, err := terminal.Draw(widgetFunc(func(area bento.Rect, buffer *bento.Buffer) {
+			buffer.SetString(area.X, area.Y, text, bento.NewStyle())
+		}))
+		require.NoError(t, err)
+	}
+
+	draw("old")
+
+	// the terminal is handed to another process, e.g. on suspend,
+	// which prints from where the viewport was
+	require.NoError(t, terminal.Clear())
+
+	for y, line := range []string{"$ vim", "$ fg"} {
+		for x, r := range line {
+			err := backend.Draw([]bento.PositionedCell{{Cell: bento.NewCell(string(r)), Position: bento.Position{X: x, Y: 1 + y}}})
+			require.NoError(t, err)
+		}
+	}
+
+	require.NoError(t, backend.SetCursorPosition(bento.Position{X: 0, Y: 3}))
+
+	// the viewport is placed below the output of the process
+	require.NoError(t, terminal.ResetViewport())
+	require.Equal(t, bento.Rect{X: 0, Y: 3, Width: 10, Height: 2}, viewportArea(terminal))
+
+	draw("new")
+
+	backend.AssertScrollbackLines(t)
+	backend.AssertBufferLines(t,
+		"          ",
+		"$ vim     ",
+		"$ fg      ",
+		"new       ",
+		"          ",
+	)
+}
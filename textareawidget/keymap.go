@@ -0,0 +1,77 @@
+package textareawidget
+
+import "github.com/metafates/bento/keymap"
+
+var _ keymap.KeyMap = (*KeyMap)(nil)
+
+// KeyMap is the key bindings of the textarea [State].
+// Typed text is inserted regardless of the bindings.
+type KeyMap struct {
+	MoveLeft           keymap.Binding
+	MoveRight          keymap.Binding
+	MoveUp             keymap.Binding
+	MoveDown           keymap.Binding
+	MoveWordLeft       keymap.Binding
+	MoveWordRight      keymap.Binding
+	MoveLineStart      keymap.Binding
+	MoveLineEnd        keymap.Binding
+	MoveDocumentStart  keymap.Binding
+	MoveDocumentEnd    keymap.Binding
+	SelectLeft         keymap.Binding
+	SelectRight        keymap.Binding
+	SelectUp           keymap.Binding
+	SelectDown         keymap.Binding
+	SelectWordLeft     keymap.Binding
+	SelectWordRight    keymap.Binding
+	SelectLineStart    keymap.Binding
+	SelectLineEnd      keymap.Binding
+	InsertNewline      keymap.Binding
+	DeleteBackward     keymap.Binding
+	DeleteForward      keymap.Binding
+	DeleteWordBackward keymap.Binding
+	DeleteLine         keymap.Binding
+	Undo               keymap.Binding
+	Redo               keymap.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		MoveLeft:           keymap.NewBinding("left").WithHelp("←", "left"),
+		MoveRight:          keymap.NewBinding("right").WithHelp("→", "right"),
+		MoveUp:             keymap.NewBinding("up").WithHelp("↑", "up"),
+		MoveDown:           keymap.NewBinding("down").WithHelp("↓", "down"),
+		MoveWordLeft:       keymap.NewBinding("ctrl+left", "alt+left").WithHelp("ctrl+←", "word left"),
+		MoveWordRight:      keymap.NewBinding("ctrl+right", "alt+right").WithHelp("ctrl+→", "word right"),
+		MoveLineStart:      keymap.NewBinding("home", "ctrl+a").WithHelp("home", "line start"),
+		MoveLineEnd:        keymap.NewBinding("end", "ctrl+e").WithHelp("end", "line end"),
+		MoveDocumentStart:  keymap.NewBinding("ctrl+home").WithHelp("ctrl+home", "start"),
+		MoveDocumentEnd:    keymap.NewBinding("ctrl+end").WithHelp("ctrl+end", "end"),
+		SelectLeft:         keymap.NewBinding("shift+left").WithHelp("shift+←", "select left"),
+		SelectRight:        keymap.NewBinding("shift+right").WithHelp("shift+→", "select right"),
+		SelectUp:           keymap.NewBinding("shift+up").WithHelp("shift+↑", "select up"),
+		SelectDown:         keymap.NewBinding("shift+down").WithHelp("shift+↓", "select down"),
+		SelectWordLeft:     keymap.NewBinding("ctrl+shift+left").WithHelp("ctrl+shift+←", "select word left"),
+		SelectWordRight:    keymap.NewBinding("ctrl+shift+right").WithHelp("ctrl+shift+→", "select word right"),
+		SelectLineStart:    keymap.NewBinding("shift+home").WithHelp("shift+home", "select to line start"),
+		SelectLineEnd:      keymap.NewBinding("shift+end").WithHelp("shift+end", "select to line end"),
+		InsertNewline:      keymap.NewBinding("enter").WithHelp("enter", "new line"),
+		DeleteBackward:     keymap.NewBinding("backspace").WithHelp("backspace", "delete"),
+		DeleteForward:      keymap.NewBinding("delete").WithHelp("delete", "delete forward"),
+		DeleteWordBackward: keymap.NewBinding("ctrl+w").WithHelp("ctrl+w", "delete word"),
+		DeleteLine:         keymap.NewBinding("ctrl+k").WithHelp("ctrl+k", "delete line"),
+		Undo:               keymap.NewBinding("ctrl+z").WithHelp("ctrl+z", "undo"),
+		Redo:               keymap.NewBinding("ctrl+y").WithHelp("ctrl+y", "redo"),
+	}
+}
+
+func (k KeyMap) ShortHelp() []keymap.Binding {
+	return []keymap.Binding{k.Undo, k.Redo}
+}
+
+func (k KeyMap) FullHelp() [][]keymap.Binding {
+	return [][]keymap.Binding{
+		{k.MoveWordLeft, k.MoveWordRight, k.MoveLineStart, k.MoveLineEnd, k.MoveDocumentStart, k.MoveDocumentEnd},
+		{k.SelectLeft, k.SelectRight, k.SelectUp, k.SelectDown, k.SelectWordLeft, k.SelectWordRight},
+		{k.DeleteWordBackward, k.DeleteLine, k.Undo, k.Redo},
+	}
+}
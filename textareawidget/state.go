@@ -0,0 +1,626 @@
+package textareawidget
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/internal/grapheme"
+	"github.com/metafates/bento/keymap"
+)
+
+// maxHistory is the number of edits kept for undo.
+const maxHistory = 100
+
+// Position is the position of the cursor in the text:
+// the line index and the grapheme index in the line.
+type Position struct {
+	Row, Col int
+}
+
+func (p Position) before(other Position) bool {
+	return p.Row < other.Row || (p.Row == other.Row && p.Col < other.Col)
+}
+
+type editKind int
+
+const (
+	editNone editKind = iota
+	editInsert
+	editDelete
+)
+
+type snapshot struct {
+	lines  []grapheme.Graphemes
+	cursor Position
+}
+
+type State struct {
+	// lines are never modified in place, edits replace them.
+	// There is always at least one line.
+	lines []grapheme.Graphemes
+
+	cursor Position
+	anchor *Position
+
+	// goalX is the cursor column in cells kept when moving between lines.
+	goalX *int
+
+	showCursor bool
+
+	// offset is the first visible row.
+	offset int
+
+	// offsetX is the horizontal scroll in cells when the lines are not wrapped.
+	offsetX int
+
+	undo     []snapshot
+	redo     []snapshot
+	lastEdit editKind
+
+	keyMap *KeyMap
+}
+
+func NewState() State {
+	return State{
+		lines:      []grapheme.Graphemes{nil},
+		showCursor: true,
+	}
+}
+
+// KeyMap returns the key bindings of the state, [DefaultKeyMap] unless set with [State.SetKeyMap].
+func (s *State) KeyMap() KeyMap {
+	if s.keyMap == nil {
+		return DefaultKeyMap()
+	}
+
+	return *s.keyMap
+}
+
+func (s *State) SetKeyMap(keyMap KeyMap) {
+	s.keyMap = &keyMap
+}
+
+func (s *State) ShowCursor(show bool) {
+	s.showCursor = show
+}
+
+// SetValue replaces the text and moves the cursor to its end.
+// The undo history is cleared.
+func (s *State) SetValue(value string) {
+	s.lines = splitLines(value)
+	s.anchor = nil
+	s.undo = nil
+	s.redo = nil
+	s.lastEdit = editNone
+
+	s.MoveDocumentEnd()
+}
+
+// Value returns the text joined with newlines.
+func (s *State) Value() string {
+	lines := make([]string, len(s.lines))
+
+	for i, l := range s.lines {
+		lines[i] = l.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// LineCount returns the number of lines.
+func (s *State) LineCount() int {
+	return max(1, len(s.lines))
+}
+
+func (s *State) Cursor() Position {
+	return s.cursor
+}
+
+// SetCursor moves the cursor to the position, clamped to the text.
+func (s *State) SetCursor(position Position) {
+	s.moveTo(position, false)
+}
+
+func (s *State) line(row int) grapheme.Graphemes {
+	if row < 0 || row >= len(s.lines) {
+		return nil
+	}
+
+	return s.lines[row]
+}
+
+func (s *State) clamp(position Position) Position {
+	position.Row = min(max(0, position.Row), s.LineCount()-1)
+	position.Col = min(max(0, position.Col), len(s.line(position.Row)))
+
+	return position
+}
+
+// moveTo moves the cursor, extending the selection if select_ is true
+// and clearing it otherwise.
+func (s *State) moveTo(position Position, select_ bool) {
+	if select_ {
+		if s.anchor == nil {
+			anchor := s.cursor
+			s.anchor = &anchor
+		}
+	} else {
+		s.anchor = nil
+	}
+
+	s.cursor = s.clamp(position)
+	s.goalX = nil
+	s.lastEdit = editNone
+}
+
+// moveVertically moves the cursor by delta lines keeping its column in cells.
+func (s *State) moveVertically(delta int, select_ bool) {
+	goalX := s.line(s.cursor.Row)[:s.cursor.Col].Width()
+	if s.goalX != nil {
+		goalX = *s.goalX
+	}
+
+	row := min(max(0, s.cursor.Row+delta), s.LineCount()-1)
+
+	line := s.line(row)
+
+	var col, x int
+
+	for col < len(line) && x+line[col].Width() <= goalX {
+		x += line[col].Width()
+		col++
+	}
+
+	s.moveTo(Position{Row: row, Col: col}, select_)
+	s.goalX = &goalX
+}
+
+func (s *State) MoveLeft()  { s.moveLeft(false) }
+func (s *State) MoveRight() { s.moveRight(false) }
+func (s *State) MoveUp()    { s.moveVertically(-1, false) }
+func (s *State) MoveDown()  { s.moveVertically(1, false) }
+
+func (s *State) moveLeft(select_ bool) {
+	// collapse the selection to its start
+	if start, _, ok := s.Selection(); ok && !select_ {
+		s.moveTo(start, false)
+		return
+	}
+
+	s.moveTo(s.leftOf(s.cursor), select_)
+}
+
+func (s *State) moveRight(select_ bool) {
+	// collapse the selection to its end
+	if _, end, ok := s.Selection(); ok && !select_ {
+		s.moveTo(end, false)
+		return
+	}
+
+	s.moveTo(s.rightOf(s.cursor), select_)
+}
+
+// MoveWordLeft moves the cursor to the start of the previous word,
+// like [inputwidget.State.MoveWordLeft]. At the line start it moves to the end of the previous line.
+func (s *State) MoveWordLeft() { s.moveTo(s.wordLeftOf(s.cursor), false) }
+
+// MoveWordRight moves the cursor to the end of the next word,
+// like [inputwidget.State.MoveWordRight]. At the line end it moves to the start of the next line.
+func (s *State) MoveWordRight() { s.moveTo(s.wordRightOf(s.cursor), false) }
+
+func (s *State) leftOf(position Position) Position {
+	if position.Col > 0 {
+		position.Col--
+	} else if position.Row > 0 {
+		position.Row--
+		position.Col = len(s.line(position.Row))
+	}
+
+	return position
+}
+
+func (s *State) rightOf(position Position) Position {
+	if position.Col < len(s.line(position.Row)) {
+		position.Col++
+	} else if position.Row < s.LineCount()-1 {
+		position.Row++
+		position.Col = 0
+	}
+
+	return position
+}
+
+func (s *State) wordLeftOf(position Position) Position {
+	if position.Col == 0 {
+		return s.leftOf(position)
+	}
+
+	position.Col = s.line(position.Row).PrevWordStart(position.Col)
+
+	return position
+}
+
+func (s *State) wordRightOf(position Position) Position {
+	if position.Col == len(s.line(position.Row)) {
+		return s.rightOf(position)
+	}
+
+	position.Col = s.line(position.Row).NextWordEnd(position.Col)
+
+	return position
+}
+
+func (s *State) MoveLineStart() {
+	s.moveTo(Position{Row: s.cursor.Row, Col: 0}, false)
+}
+
+func (s *State) MoveLineEnd() {
+	s.moveTo(Position{Row: s.cursor.Row, Col: len(s.line(s.cursor.Row))}, false)
+}
+
+func (s *State) MoveDocumentStart() {
+	s.moveTo(Position{}, false)
+}
+
+func (s *State) MoveDocumentEnd() {
+	row := s.LineCount() - 1
+
+	s.moveTo(Position{Row: row, Col: len(s.line(row))}, false)
+}
+
+// SelectAll selects the whole text.
+func (s *State) SelectAll() {
+	s.MoveDocumentStart()
+
+	row := s.LineCount() - 1
+
+	s.moveTo(Position{Row: row, Col: len(s.line(row))}, true)
+}
+
+// Unselect clears the selection keeping the cursor.
+func (s *State) Unselect() {
+	s.anchor = nil
+}
+
+// Selection returns the ordered bounds of the selected text, the end is exclusive.
+// Returns ok = false if nothing is selected.
+func (s *State) Selection() (start, end Position, ok bool) {
+	if s.anchor == nil || *s.anchor == s.cursor {
+		return Position{}, Position{}, false
+	}
+
+	start, end = *s.anchor, s.cursor
+	if end.before(start) {
+		start, end = end, start
+	}
+
+	return start, end, true
+}
+
+// SelectedText returns the selected text joined with newlines.
+func (s *State) SelectedText() string {
+	start, end, ok := s.Selection()
+	if !ok {
+		return ""
+	}
+
+	if start.Row == end.Row {
+		return s.line(start.Row)[start.Col:end.Col].String()
+	}
+
+	lines := []string{s.line(start.Row)[start.Col:].String()}
+
+	for row := start.Row + 1; row < end.Row; row++ {
+		lines = append(lines, s.line(row).String())
+	}
+
+	lines = append(lines, s.line(end.Row)[:end.Col].String())
+
+	return strings.Join(lines, "\n")
+}
+
+// Insert inserts the text at the cursor, replacing the selection.
+func (s *State) Insert(text string) {
+	if text == "" {
+		return
+	}
+
+	if _, _, ok := s.Selection(); ok {
+		// replacing the selection is undone on its own
+		s.edit(editNone)
+		s.lastEdit = editInsert
+	} else {
+		s.edit(editInsert)
+	}
+
+	s.deleteSelection()
+
+	inserted := splitLines(text)
+
+	line := s.line(s.cursor.Row)
+	before, after := line[:s.cursor.Col], line[s.cursor.Col:]
+
+	last := len(inserted) - 1
+	cursor := Position{Row: s.cursor.Row + last, Col: len(inserted[last])}
+
+	if last == 0 {
+		cursor.Col += len(before)
+	}
+
+	inserted[0] = slices.Concat(before, inserted[0])
+	inserted[last] = slices.Concat(inserted[last], after)
+
+	s.lines = slices.Concat(s.lines[:s.cursor.Row], inserted, s.lines[s.cursor.Row+1:])
+	s.cursor = cursor
+}
+
+// Paste inserts the text in place of the selection as a separate undo step.
+func (s *State) Paste(text string) {
+	s.lastEdit = editNone
+	s.Insert(text)
+	s.lastEdit = editNone
+}
+
+// InsertNewline splits the line at the cursor.
+func (s *State) InsertNewline() {
+	s.Insert("\n")
+}
+
+// DeleteBackward deletes the selection or the grapheme before the cursor,
+// joining the line with the previous one at the line start.
+func (s *State) DeleteBackward() {
+	if s.deleteSelectionEdit() {
+		return
+	}
+
+	if start := s.leftOf(s.cursor); start != s.cursor {
+		s.edit(editDelete)
+		s.deleteRange(start, s.cursor)
+	}
+}
+
+// DeleteForward deletes the selection or the grapheme after the cursor,
+// joining the line with the next one at the line end.
+func (s *State) DeleteForward() {
+	if s.deleteSelectionEdit() {
+		return
+	}
+
+	if end := s.rightOf(s.cursor); end != s.cursor {
+		s.edit(editDelete)
+		s.deleteRange(s.cursor, end)
+	}
+}
+
+// DeleteWordBackward deletes the selection or the word before the cursor.
+func (s *State) DeleteWordBackward() {
+	if s.deleteSelectionEdit() {
+		return
+	}
+
+	if start := s.wordLeftOf(s.cursor); start != s.cursor {
+		s.edit(editDelete)
+		s.deleteRange(start, s.cursor)
+	}
+}
+
+// DeleteLine deletes the line under the cursor.
+func (s *State) DeleteLine() {
+	s.edit(editNone)
+	s.anchor = nil
+
+	if s.LineCount() == 1 {
+		s.lines = []grapheme.Graphemes{nil}
+	} else {
+		s.lines = slices.Concat(s.lines[:s.cursor.Row], s.lines[s.cursor.Row+1:])
+	}
+
+	s.cursor = s.clamp(Position{Row: s.cursor.Row})
+}
+
+func (s *State) deleteSelectionEdit() bool {
+	if _, _, ok := s.Selection(); !ok {
+		return false
+	}
+
+	s.edit(editNone)
+	s.deleteSelection()
+
+	return true
+}
+
+func (s *State) deleteSelection() {
+	start, end, ok := s.Selection()
+
+	s.anchor = nil
+
+	if ok {
+		s.deleteRange(start, end)
+	}
+}
+
+// deleteRange deletes the text between the ordered positions and moves the cursor to the start.
+func (s *State) deleteRange(start, end Position) {
+	joined := slices.Concat(s.line(start.Row)[:start.Col], s.line(end.Row)[end.Col:])
+
+	s.lines = slices.Concat(s.lines[:start.Row], []grapheme.Graphemes{joined}, s.lines[end.Row+1:])
+	s.cursor = start
+	s.goalX = nil
+}
+
+// edit records the state before the edit of the given kind for undo.
+// Consecutive edits of the same kind, e.g. typing, are undone at once.
+func (s *State) edit(kind editKind) {
+	if len(s.lines) == 0 {
+		s.lines = []grapheme.Graphemes{nil}
+	}
+
+	s.redo = nil
+
+	if kind != editNone && kind == s.lastEdit {
+		return
+	}
+
+	s.undo = append(s.undo, s.snapshot())
+	if len(s.undo) > maxHistory {
+		s.undo = s.undo[1:]
+	}
+
+	s.lastEdit = kind
+	s.goalX = nil
+}
+
+func (s *State) snapshot() snapshot {
+	return snapshot{lines: slices.Clone(s.lines), cursor: s.cursor}
+}
+
+func (s *State) restore(snap snapshot) {
+	s.lines = snap.lines
+	s.cursor = snap.cursor
+	s.anchor = nil
+	s.goalX = nil
+	s.lastEdit = editNone
+}
+
+// Undo reverts the last edit.
+func (s *State) Undo() {
+	if len(s.undo) == 0 {
+		return
+	}
+
+	s.redo = append(s.redo, s.snapshot())
+	s.restore(s.undo[len(s.undo)-1])
+	s.undo = s.undo[:len(s.undo)-1]
+}
+
+// Redo reapplies the last undone edit.
+func (s *State) Redo() {
+	if len(s.redo) == 0 {
+		return
+	}
+
+	s.undo = append(s.undo, s.snapshot())
+	s.restore(s.redo[len(s.redo)-1])
+	s.redo = s.redo[:len(s.redo)-1]
+}
+
+func (s *State) TryUpdate(msg bento.Msg) (bool, bento.Cmd) {
+	switch msg := msg.(type) {
+	case bento.KeyMsg:
+		return s.update(bento.Key(msg)), nil
+
+	case bento.PasteMsg:
+		s.Paste(string(msg))
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
+
+func (s *State) update(key bento.Key) bool {
+	keyMap := s.KeyMap()
+
+	switch {
+	case keymap.Matches(key, keyMap.MoveLeft):
+		s.moveLeft(false)
+
+	case keymap.Matches(key, keyMap.MoveRight):
+		s.moveRight(false)
+
+	case keymap.Matches(key, keyMap.MoveUp):
+		s.moveVertically(-1, false)
+
+	case keymap.Matches(key, keyMap.MoveDown):
+		s.moveVertically(1, false)
+
+	case keymap.Matches(key, keyMap.SelectLeft):
+		s.moveLeft(true)
+
+	case keymap.Matches(key, keyMap.SelectRight):
+		s.moveRight(true)
+
+	case keymap.Matches(key, keyMap.SelectUp):
+		s.moveVertically(-1, true)
+
+	case keymap.Matches(key, keyMap.SelectDown):
+		s.moveVertically(1, true)
+
+	case keymap.Matches(key, keyMap.MoveWordLeft):
+		s.MoveWordLeft()
+
+	case keymap.Matches(key, keyMap.MoveWordRight):
+		s.MoveWordRight()
+
+	case keymap.Matches(key, keyMap.SelectWordLeft):
+		s.moveTo(s.wordLeftOf(s.cursor), true)
+
+	case keymap.Matches(key, keyMap.SelectWordRight):
+		s.moveTo(s.wordRightOf(s.cursor), true)
+
+	case keymap.Matches(key, keyMap.MoveLineStart):
+		s.MoveLineStart()
+
+	case keymap.Matches(key, keyMap.MoveLineEnd):
+		s.MoveLineEnd()
+
+	case keymap.Matches(key, keyMap.SelectLineStart):
+		s.moveTo(Position{Row: s.cursor.Row}, true)
+
+	case keymap.Matches(key, keyMap.SelectLineEnd):
+		s.moveTo(Position{Row: s.cursor.Row, Col: len(s.line(s.cursor.Row))}, true)
+
+	case keymap.Matches(key, keyMap.MoveDocumentStart):
+		s.MoveDocumentStart()
+
+	case keymap.Matches(key, keyMap.MoveDocumentEnd):
+		s.MoveDocumentEnd()
+
+	case keymap.Matches(key, keyMap.InsertNewline):
+		s.InsertNewline()
+
+	case keymap.Matches(key, keyMap.DeleteBackward):
+		s.DeleteBackward()
+
+	case keymap.Matches(key, keyMap.DeleteForward):
+		s.DeleteForward()
+
+	case keymap.Matches(key, keyMap.DeleteWordBackward):
+		s.DeleteWordBackward()
+
+	case keymap.Matches(key, keyMap.DeleteLine):
+		s.DeleteLine()
+
+	case keymap.Matches(key, keyMap.Undo):
+		s.Undo()
+
+	case keymap.Matches(key, keyMap.Redo):
+		s.Redo()
+
+	case !key.Alt && (key.Type == bento.KeyRunes || key.Type == bento.KeySpace):
+		s.Insert(string(key.Runes))
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// splitLines splits the text into lines of graphemes, handling \n and \r\n line endings.
+func splitLines(text string) []grapheme.Graphemes {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	parts := strings.Split(text, "\n")
+	lines := make([]grapheme.Graphemes, len(parts))
+
+	for i, p := range parts {
+		if p != "" {
+			lines[i] = grapheme.NewGraphemes(p)
+		}
+	}
+
+	return lines
+}
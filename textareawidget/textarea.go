@@ -0,0 +1,314 @@
+package textareawidget
+
+import (
+	"strconv"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/blockwidget"
+	"github.com/metafates/bento/internal/grapheme"
+	"github.com/metafates/bento/internal/reflow"
+	"github.com/metafates/bento/textwidget"
+)
+
+var _ bento.StatefulWidget[*State] = (*Textarea)(nil)
+
+// Textarea is a multi-line text editor.
+type Textarea struct {
+	block            *blockwidget.Block
+	style            bento.Style
+	cursorStyle      bento.Style
+	cursorShape      *bento.CursorShape
+	selectionStyle   bento.Style
+	placeholder      string
+	placeholderStyle bento.Style
+	lineNumbers      bool
+	lineNumberStyle  bento.Style
+	wrap             bool
+}
+
+func New() Textarea {
+	return Textarea{
+		block:            nil,
+		style:            bento.NewStyle(),
+		cursorStyle:      bento.NewStyle().Reversed(),
+		cursorShape:      nil,
+		selectionStyle:   bento.NewStyle().Reversed(),
+		placeholder:      "",
+		placeholderStyle: bento.NewStyle().Dim().Italic(),
+		lineNumbers:      false,
+		lineNumberStyle:  bento.NewStyle().Dim(),
+		wrap:             false,
+	}
+}
+
+func (t Textarea) WithBlock(block blockwidget.Block) Textarea {
+	t.block = &block
+	return t
+}
+
+func (t Textarea) WithStyle(style bento.Style) Textarea {
+	t.style = style
+	return t
+}
+
+func (t Textarea) WithCursorStyle(style bento.Style) Textarea {
+	t.cursorStyle = style
+	return t
+}
+
+// WithTerminalCursor makes the textarea place the real terminal cursor with the given shape
+// instead of highlighting the cursor cell with the cursor style.
+func (t Textarea) WithTerminalCursor(shape bento.CursorShape) Textarea {
+	t.cursorShape = &shape
+	return t
+}
+
+func (t Textarea) WithSelectionStyle(style bento.Style) Textarea {
+	t.selectionStyle = style
+	return t
+}
+
+func (t Textarea) WithPlaceholder(placeholder string) Textarea {
+	t.placeholder = placeholder
+	return t
+}
+
+func (t Textarea) WithPlaceholderStyle(style bento.Style) Textarea {
+	t.placeholderStyle = style
+	return t
+}
+
+// WithLineNumbers shows the line numbers gutter.
+func (t Textarea) WithLineNumbers(show bool) Textarea {
+	t.lineNumbers = show
+	return t
+}
+
+func (t Textarea) WithLineNumberStyle(style bento.Style) Textarea {
+	t.lineNumberStyle = style
+	return t
+}
+
+// WithWrap soft wraps the lines at word boundaries instead of scrolling horizontally.
+func (t Textarea) WithWrap(wrap bool) Textarea {
+	t.wrap = wrap
+	return t
+}
+
+func (t Textarea) RenderStateful(area bento.Rect, buffer *bento.Buffer, state *State) {
+	buffer.SetStyle(area, t.style)
+
+	if t.block != nil {
+		t.block.Render(area, buffer)
+		area = t.block.Inner(area)
+	}
+
+	var gutterWidth int
+	if t.lineNumbers {
+		gutterWidth = len(strconv.Itoa(state.LineCount())) + 1
+	}
+
+	textArea := area.IndentX(gutterWidth)
+	if textArea.IsEmpty() {
+		return
+	}
+
+	// leave a cell for the cursor at the end of the wrapped line
+	rows := t.visualRows(state, max(1, textArea.Width-1))
+	cursorRow, cursorX := cursorOnRows(state, rows)
+
+	state.offset = min(state.offset, max(0, len(rows)-textArea.Height))
+
+	if cursorRow < state.offset {
+		state.offset = cursorRow
+	} else if cursorRow >= state.offset+textArea.Height {
+		state.offset = cursorRow - textArea.Height + 1
+	}
+
+	if t.wrap {
+		state.offsetX = 0
+	} else if cursorX < state.offsetX {
+		state.offsetX = cursorX
+	} else if cursorX >= state.offsetX+textArea.Width {
+		state.offsetX = cursorX - textArea.Width + 1
+	}
+
+	for i := state.offset; i < min(len(rows), state.offset+textArea.Height); i++ {
+		y := textArea.Y + i - state.offset
+
+		if t.lineNumbers && rows[i].start == 0 {
+			number := strconv.Itoa(rows[i].line + 1)
+
+			buffer.SetStringN(area.X+gutterWidth-1-len(number), y, number, gutterWidth, t.lineNumberStyle)
+		}
+
+		t.renderRow(textArea, y, buffer, state, rows[i])
+	}
+
+	if state.Value() == "" && t.placeholder != "" {
+		textwidget.NewLineStr(t.placeholder).
+			WithStyle(t.placeholderStyle).
+			Render(bento.Rect{X: textArea.X, Y: textArea.Y, Width: textArea.Width, Height: 1}, buffer)
+	}
+
+	if state.showCursor {
+		t.renderCursor(textArea, buffer, state, cursorRow, cursorX)
+	}
+}
+
+func (t Textarea) renderRow(area bento.Rect, y int, buffer *bento.Buffer, state *State, row visualRow) {
+	line := state.line(row.line)
+
+	start, end, hasSelection := state.Selection()
+
+	selected := func(col int) bool {
+		position := Position{Row: row.line, Col: col}
+
+		return hasSelection && !position.before(start) && position.before(end)
+	}
+
+	x := area.X - state.offsetX
+
+	for col := row.start; col < row.end && x < area.Right(); col++ {
+		g := line[col]
+		width := g.Width()
+
+		if x >= area.X && x+width <= area.Right() {
+			buffer.SetStringN(x, y, g.String(), width, bento.NewStyle())
+
+			if selected(col) {
+				buffer.SetStyle(bento.Rect{X: x, Y: y, Width: width, Height: 1}, t.selectionStyle)
+			}
+		}
+
+		x += width
+	}
+
+	// the selected line break
+	if row.last && selected(len(line)) && x >= area.X && x < area.Right() {
+		buffer.SetStyle(bento.Rect{X: x, Y: y, Width: 1, Height: 1}, t.selectionStyle)
+	}
+}
+
+func (t Textarea) renderCursor(area bento.Rect, buffer *bento.Buffer, state *State, cursorRow, cursorX int) {
+	if cursorRow < state.offset || cursorRow >= state.offset+area.Height {
+		return
+	}
+
+	position := bento.Position{
+		X: min(area.Right()-1, area.X+cursorX-state.offsetX),
+		Y: area.Y + cursorRow - state.offset,
+	}
+
+	if t.cursorShape != nil {
+		buffer.SetCursorPosition(position)
+		buffer.SetCursorShape(*t.cursorShape)
+
+		return
+	}
+
+	buffer.SetStyle(bento.Rect{X: position.X, Y: position.Y, Width: 1, Height: 1}, t.cursorStyle)
+}
+
+// visualRow is a rendered row, i.e. a whole line or its wrapped part.
+type visualRow struct {
+	line       int
+	start, end int
+
+	// last reports whether it is the last row of the line.
+	last bool
+}
+
+func (t Textarea) visualRows(state *State, width int) []visualRow {
+	rows := make([]visualRow, 0, state.LineCount())
+
+	for i := 0; i < state.LineCount(); i++ {
+		line := state.line(i)
+
+		if !t.wrap || line.Width() <= width {
+			rows = append(rows, visualRow{line: i, start: 0, end: len(line), last: true})
+			continue
+		}
+
+		starts := wrapStarts(line, width)
+
+		for j, start := range starts {
+			end := len(line)
+			if j+1 < len(starts) {
+				end = starts[j+1]
+			}
+
+			rows = append(rows, visualRow{line: i, start: start, end: end, last: j == len(starts)-1})
+		}
+	}
+
+	return rows
+}
+
+// cursorOnRows returns the row of the cursor and its column in cells relative to the row start.
+func cursorOnRows(state *State, rows []visualRow) (row, x int) {
+	cursor := state.Cursor()
+
+	for i, r := range rows {
+		if r.line != cursor.Row || cursor.Col < r.start || (cursor.Col >= r.end && !r.last) {
+			continue
+		}
+
+		return i, state.line(r.line)[r.start:cursor.Col].Width()
+	}
+
+	return 0, 0
+}
+
+// wrapStarts returns the indexes of the graphemes starting the wrapped parts of the line.
+//
+// The word wrapper drops the whitespace at the wrap points,
+// so its lines are matched back to the graphemes in order.
+func wrapStarts(line grapheme.Graphemes, width int) []int {
+	styled := make([]textwidget.StyledGrapheme, len(line))
+
+	for i, g := range line {
+		styled[i] = textwidget.StyledGrapheme{Grapheme: g, Style: bento.NewStyle()}
+	}
+
+	wrapper := reflow.NewWordWrapper([]reflow.InputLine{{
+		Graphemes: styled,
+		Alignment: bento.AlignmentLeft,
+	}}, width, false)
+
+	starts := []int{0}
+
+	var index int
+
+	for {
+		wrapped, ok := wrapper.NextLine()
+		if !ok {
+			break
+		}
+
+		start := -1
+
+		for _, g := range wrapped.Line {
+			// the wrapper pads its lines with empty graphemes
+			if g.IsEmpty() {
+				continue
+			}
+
+			for index < len(line) && line[index].String() != g.String() {
+				index++
+			}
+
+			if start < 0 {
+				start = index
+			}
+
+			index++
+		}
+
+		if start > starts[len(starts)-1] && start < len(line) {
+			starts = append(starts, start)
+		}
+	}
+
+	return starts
+}
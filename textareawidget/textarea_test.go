@@ -0,0 +1,192 @@
+package textareawidget
+
+import (
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/stretchr/testify/require"
+)
+
+func statefulWidget(widget Textarea, state *State, width, height int) bento.Buffer {
+	buffer := bento.NewBufferEmpty(bento.Rect{Width: width, Height: height})
+
+	widget.RenderStateful(buffer.Area(), &buffer, state)
+
+	return buffer
+}
+
+func send(t *testing.T, state *State, keys ...bento.KeyMsg) {
+	t.Helper()
+
+	for _, key := range keys {
+		handled, _ := state.TryUpdate(key)
+		require.True(t, handled, "key %q", key.String())
+	}
+}
+
+func typed(s string) []bento.KeyMsg {
+	var keys []bento.KeyMsg
+
+	for _, r := range s {
+		switch r {
+		case '\n':
+			keys = append(keys, bento.KeyMsg{Type: bento.KeyEnter})
+		case ' ':
+			keys = append(keys, bento.KeyMsg{Type: bento.KeySpace, Runes: []rune{r}})
+		default:
+			keys = append(keys, bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	return keys
+}
+
+func key(keyType bento.KeyType) bento.KeyMsg {
+	return bento.KeyMsg{Type: keyType}
+}
+
+func TestState_Editing(t *testing.T) {
+	state := NewState()
+
+	send(t, &state, typed("hello\nworld")...)
+	require.Equal(t, "hello\nworld", state.Value())
+	require.Equal(t, Position{Row: 1, Col: 5}, state.Cursor())
+
+	// join the lines
+	send(t, &state, key(bento.KeyHome), key(bento.KeyBackspace))
+	require.Equal(t, "helloworld", state.Value())
+	require.Equal(t, Position{Row: 0, Col: 5}, state.Cursor())
+
+	send(t, &state, key(bento.KeyDelete), key(bento.KeyDelete))
+	require.Equal(t, "hellorld", state.Value())
+
+	// consecutive deletes are undone at once
+	send(t, &state, key(bento.KeyCtrlZ))
+	require.Equal(t, "hello\nworld", state.Value())
+
+	send(t, &state, key(bento.KeyCtrlZ))
+	require.Equal(t, "", state.Value())
+
+	send(t, &state, key(bento.KeyCtrlY), key(bento.KeyCtrlY))
+	require.Equal(t, "hellorld", state.Value())
+
+	state.Insert("a\r\nb")
+	require.Equal(t, "helloa\nbrld", state.Value())
+	require.Equal(t, Position{Row: 1, Col: 1}, state.Cursor())
+}
+
+func TestState_WordMotions(t *testing.T) {
+	state := NewState()
+	state.SetValue("foo  bar\nbaz")
+	state.SetCursor(Position{Row: 0, Col: 0})
+
+	state.MoveWordRight()
+	require.Equal(t, Position{Row: 0, Col: 3}, state.Cursor())
+
+	state.MoveWordRight()
+	require.Equal(t, Position{Row: 0, Col: 8}, state.Cursor())
+
+	// to the next line
+	state.MoveWordRight()
+	require.Equal(t, Position{Row: 1, Col: 0}, state.Cursor())
+
+	state.MoveWordLeft()
+	require.Equal(t, Position{Row: 0, Col: 8}, state.Cursor())
+
+	state.MoveWordLeft()
+	require.Equal(t, Position{Row: 0, Col: 5}, state.Cursor())
+
+	send(t, &state, key(bento.KeyCtrlW))
+	require.Equal(t, "bar\nbaz", state.Value())
+}
+
+func TestState_Selection(t *testing.T) {
+	state := NewState()
+	state.SetValue("one\ntwo\nthree")
+	state.SetCursor(Position{Row: 0, Col: 1})
+
+	send(t, &state, key(bento.KeyShiftDown), key(bento.KeyShiftRight), key(bento.KeyCtrlShiftRight))
+
+	require.Equal(t, "ne\ntwo", state.SelectedText())
+
+	send(t, &state, typed("X")...)
+	require.Equal(t, "oX\nthree", state.Value())
+
+	_, _, ok := state.Selection()
+	require.False(t, ok)
+
+	send(t, &state, key(bento.KeyCtrlZ))
+	require.Equal(t, "one\ntwo\nthree", state.Value())
+}
+
+func TestState_VerticalMotionKeepsColumn(t *testing.T) {
+	state := NewState()
+	state.SetValue("long line\nab\nanother")
+
+	state.SetCursor(Position{Row: 0, Col: 6})
+	state.MoveDown()
+	require.Equal(t, Position{Row: 1, Col: 2}, state.Cursor())
+
+	state.MoveDown()
+	require.Equal(t, Position{Row: 2, Col: 6}, state.Cursor())
+}
+
+func TestTextarea_ScrollAndLineNumbers(t *testing.T) {
+	state := NewState()
+	state.ShowCursor(false)
+	state.SetValue("first\nsecond line\nthird\nfourth")
+
+	textarea := New().WithLineNumbers(true)
+
+	buffer := statefulWidget(textarea, &state, 9, 2)
+	bentotest.AssertBufferLines(t, []string{
+		"3 third  ",
+		"4 fourth ",
+	}, buffer)
+
+	state.SetCursor(Position{Row: 1, Col: 11})
+
+	buffer = statefulWidget(textarea, &state, 9, 2)
+	bentotest.AssertBufferLines(t, []string{
+		"2 d line ",
+		"3        ",
+	}, buffer)
+}
+
+func TestTextarea_Wrap(t *testing.T) {
+	state := NewState()
+	state.SetValue("the quick brown fox\nend")
+	state.SetCursor(Position{Row: 0, Col: 12})
+
+	textarea := New().WithWrap(true)
+
+	buffer := statefulWidget(textarea, &state, 10, 4)
+	bentotest.AssertBufferLines(t, []string{
+		"the quick ",
+		"brown fox ",
+		"end       ",
+		"          ",
+	}, buffer)
+
+	cell := buffer.CellAt(bento.Position{X: 2, Y: 1})
+	require.NotZero(t, cell.Modifier&bento.ModifierReversed)
+
+	// the wrapped row of the cursor is scrolled into view
+	buffer = statefulWidget(textarea, &state, 10, 1)
+	bentotest.AssertBufferLines(t, []string{"brown fox "}, buffer)
+}
+
+func TestState_Paste(t *testing.T) {
+	state := NewState()
+	state.SetValue("one two")
+	state.SetCursor(Position{Row: 0, Col: 3})
+
+	handled, _ := state.TryUpdate(bento.PasteMsg(" and\r\nthree"))
+	require.True(t, handled)
+	require.Equal(t, "one and\nthree two", state.Value())
+	require.Equal(t, Position{Row: 1, Col: 5}, state.Cursor())
+
+	send(t, &state, key(bento.KeyCtrlZ))
+	require.Equal(t, "one two", state.Value())
+}
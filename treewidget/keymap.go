@@ -0,0 +1,39 @@
+package treewidget
+
+import "github.com/metafates/bento/keymap"
+
+var _ keymap.KeyMap = (*KeyMap)(nil)
+
+// KeyMap is the key bindings of the tree [State].
+type KeyMap struct {
+	SelectNext     keymap.Binding
+	SelectPrevious keymap.Binding
+	SelectFirst    keymap.Binding
+	SelectLast     keymap.Binding
+	Open           keymap.Binding
+	Close          keymap.Binding
+	Toggle         keymap.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		SelectNext:     keymap.NewBinding("j", "down").WithHelp("↓/j", "down"),
+		SelectPrevious: keymap.NewBinding("k", "up").WithHelp("↑/k", "up"),
+		SelectFirst:    keymap.NewBinding("g", "home").WithHelp("g", "first"),
+		SelectLast:     keymap.NewBinding("G", "end").WithHelp("G", "last"),
+		Open:           keymap.NewBinding("l", "right").WithHelp("→/l", "open"),
+		Close:          keymap.NewBinding("h", "left").WithHelp("←/h", "close"),
+		Toggle:         keymap.NewBinding("enter", " ").WithHelp("enter", "toggle"),
+	}
+}
+
+func (k KeyMap) ShortHelp() []keymap.Binding {
+	return []keymap.Binding{k.SelectPrevious, k.SelectNext, k.Toggle}
+}
+
+func (k KeyMap) FullHelp() [][]keymap.Binding {
+	return [][]keymap.Binding{
+		{k.SelectPrevious, k.SelectNext, k.SelectFirst, k.SelectLast},
+		{k.Open, k.Close, k.Toggle},
+	}
+}
@@ -0,0 +1,36 @@
+package treewidget
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+var lastID atomic.Int64
+
+func nextID() int {
+	return int(lastID.Add(1))
+}
+
+// LoadedMsg delivers the lazily loaded children of a node, see [State.Load].
+type LoadedMsg struct {
+	path     []string
+	children []Node
+	err      error
+
+	id int
+}
+
+// ID of the state that this message belongs to.
+func (m LoadedMsg) ID() int {
+	return m.id
+}
+
+// Path returns the path of the node the children were loaded for.
+func (m LoadedMsg) Path() []string {
+	return slices.Clone(m.path)
+}
+
+// Err returns the error of the [Loader], if any.
+func (m LoadedMsg) Err() error {
+	return m.err
+}
@@ -0,0 +1,64 @@
+package treewidget
+
+import (
+	"github.com/metafates/bento/textwidget"
+)
+
+// Node is a node of the tree.
+//
+// Nodes are identified by the path of their ids from the root,
+// so the ids must be unique among siblings.
+type Node struct {
+	id       string
+	label    textwidget.Line
+	children []Node
+	lazy     bool
+}
+
+func NewNode(id string, label textwidget.Line, children ...Node) Node {
+	return Node{
+		id:       id,
+		label:    label,
+		children: children,
+		lazy:     false,
+	}
+}
+
+// NewNodeStr returns a new node labeled with its id.
+func NewNodeStr(id string, children ...Node) Node {
+	return NewNode(id, textwidget.NewLineStr(id), children...)
+}
+
+func (n Node) WithLabel(label textwidget.Line) Node {
+	n.label = label
+	return n
+}
+
+func (n Node) WithChildren(children ...Node) Node {
+	n.children = children
+	return n
+}
+
+// WithLazyChildren marks the node as expandable with children loaded
+// by the [Loader] of the state once the node is opened, see [State.SetLoader].
+func (n Node) WithLazyChildren() Node {
+	n.lazy = true
+	return n
+}
+
+func (n Node) ID() string {
+	return n.id
+}
+
+func (n Node) Label() textwidget.Line {
+	return n.label
+}
+
+func (n Node) Children() []Node {
+	return n.children
+}
+
+// Expandable reports whether the node has or may have children.
+func (n Node) Expandable() bool {
+	return n.lazy || len(n.children) > 0
+}
@@ -0,0 +1,343 @@
+package treewidget
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/keymap"
+)
+
+var _ bento.TryUpdater = (*State)(nil)
+
+type State struct {
+	id       int
+	offset   int
+	selected []string
+	opened   map[string]struct{}
+	loader   Loader
+	loaded   map[string][]Node
+	loadErrs map[string]error
+
+	// visible are the rendered nodes in order.
+	// Navigation moves through the nodes of the last render.
+	visible []visibleNode
+
+	keyMap *KeyMap
+}
+
+type visibleNode struct {
+	path       []string
+	expandable bool
+	lazy       bool
+}
+
+func NewState() State {
+	return State{
+		id:       nextID(),
+		offset:   0,
+		selected: nil,
+		opened:   make(map[string]struct{}),
+		loader:   nil,
+		loaded:   make(map[string][]Node),
+		loadErrs: make(map[string]error),
+		visible:  nil,
+		keyMap:   nil,
+	}
+}
+
+// ID of the state. This can be helpful when routing [LoadedMsg].
+func (s *State) ID() int {
+	return s.id
+}
+
+// SetLoader sets the loader for the children of lazy nodes.
+func (s *State) SetLoader(loader Loader) {
+	s.loader = loader
+}
+
+// KeyMap returns the key bindings of the state, [DefaultKeyMap] unless set with [State.SetKeyMap].
+func (s *State) KeyMap() KeyMap {
+	if s.keyMap == nil {
+		return DefaultKeyMap()
+	}
+
+	return *s.keyMap
+}
+
+func (s *State) SetKeyMap(keyMap KeyMap) {
+	s.keyMap = &keyMap
+}
+
+func (s *State) TryUpdate(msg bento.Msg) (bool, bento.Cmd) {
+	switch msg := msg.(type) {
+	case bento.KeyMsg:
+		return s.update(bento.Key(msg))
+
+	case LoadedMsg:
+		if msg.id != s.id {
+			return false, nil
+		}
+
+		s.setLoaded(msg)
+
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
+
+func (s *State) update(key bento.Key) (bool, bento.Cmd) {
+	keyMap := s.KeyMap()
+
+	switch {
+	case keymap.Matches(key, keyMap.SelectNext):
+		s.SelectNext()
+		return true, nil
+
+	case keymap.Matches(key, keyMap.SelectPrevious):
+		s.SelectPrevious()
+		return true, nil
+
+	case keymap.Matches(key, keyMap.Close):
+		s.CloseOrSelectParent()
+		return true, nil
+
+	case keymap.Matches(key, keyMap.Open):
+		s.OpenSelected()
+		return true, s.loadSelected()
+
+	case keymap.Matches(key, keyMap.Toggle):
+		s.ToggleSelected()
+		return true, s.loadSelected()
+
+	case keymap.Matches(key, keyMap.SelectFirst):
+		s.SelectFirst()
+		return true, nil
+
+	case keymap.Matches(key, keyMap.SelectLast):
+		s.SelectLast()
+		return true, nil
+
+	default:
+		return false, nil
+	}
+}
+
+func (s *State) Offset() int {
+	return s.offset
+}
+
+func (s *State) SetOffset(offset int) {
+	s.offset = offset
+}
+
+// Select selects the node with the given path of ids.
+func (s *State) Select(path ...string) {
+	s.selected = slices.Clone(path)
+}
+
+// Selected returns the path of the selected node.
+// Returns ok = false if no node is selected.
+func (s *State) Selected() (path []string, ok bool) {
+	if s.selected == nil {
+		return nil, false
+	}
+
+	return slices.Clone(s.selected), true
+}
+
+func (s *State) Unselect() {
+	s.selected = nil
+	s.offset = 0
+}
+
+func (s *State) SelectNext() {
+	s.selectVisible(func(index int) int { return index + 1 })
+}
+
+func (s *State) SelectPrevious() {
+	s.selectVisible(func(index int) int { return index - 1 })
+}
+
+func (s *State) SelectFirst() {
+	s.selectVisible(func(int) int { return 0 })
+}
+
+func (s *State) SelectLast() {
+	s.selectVisible(func(int) int { return len(s.visible) - 1 })
+}
+
+// selectVisible selects the visible node at the index returned by move
+// for the index of the currently selected node, or -1 if none.
+func (s *State) selectVisible(move func(index int) int) {
+	if len(s.visible) == 0 {
+		return
+	}
+
+	index := move(s.selectedIndex())
+
+	s.selected = s.visible[min(len(s.visible)-1, max(0, index))].path
+}
+
+func (s *State) selectedIndex() int {
+	if s.selected == nil {
+		return -1
+	}
+
+	return slices.IndexFunc(s.visible, func(node visibleNode) bool {
+		return slices.Equal(node.path, s.selected)
+	})
+}
+
+// selectedExpandable reports whether the selected node was rendered as expandable.
+func (s *State) selectedExpandable() bool {
+	index := s.selectedIndex()
+
+	return index >= 0 && s.visible[index].expandable
+}
+
+// OpenSelected opens the selected node if it is expandable.
+func (s *State) OpenSelected() {
+	if s.selectedExpandable() {
+		s.Open(s.selected...)
+	}
+}
+
+// ToggleSelected opens the selected node if it is closed and closes it otherwise.
+func (s *State) ToggleSelected() {
+	if s.selectedExpandable() {
+		s.Toggle(s.selected...)
+	}
+}
+
+// CloseOrSelectParent closes the selected node if it is opened, otherwise selects its parent.
+func (s *State) CloseOrSelectParent() {
+	if s.selected == nil {
+		return
+	}
+
+	if s.selectedExpandable() && s.IsOpened(s.selected...) {
+		s.Close(s.selected...)
+		return
+	}
+
+	if len(s.selected) > 1 {
+		s.selected = s.selected[:len(s.selected)-1]
+	}
+}
+
+// Open opens the node with the given path.
+// The children of a lazy node are not loaded, see [State.Load].
+func (s *State) Open(path ...string) {
+	if s.opened == nil {
+		s.opened = make(map[string]struct{})
+	}
+
+	s.opened[pathKey(path)] = struct{}{}
+}
+
+func (s *State) Close(path ...string) {
+	delete(s.opened, pathKey(path))
+}
+
+func (s *State) Toggle(path ...string) {
+	if s.IsOpened(path...) {
+		s.Close(path...)
+	} else {
+		s.Open(path...)
+	}
+}
+
+func (s *State) IsOpened(path ...string) bool {
+	_, ok := s.opened[pathKey(path)]
+	return ok
+}
+
+func (s *State) CloseAll() {
+	clear(s.opened)
+}
+
+// Load returns the command loading the children of the lazy node with the given path
+// with the [Loader] of the state. The children are stored once the [LoadedMsg] is passed to [State.TryUpdate].
+//
+// It returns nil if there is no loader or the children are already loaded.
+func (s *State) Load(path ...string) bento.Cmd {
+	if s.loader == nil {
+		return nil
+	}
+
+	if _, ok := s.loaded[pathKey(path)]; ok {
+		return nil
+	}
+
+	loader := s.loader
+	path = slices.Clone(path)
+	id := s.id
+
+	return func() bento.Msg {
+		children, err := loader(path)
+
+		return LoadedMsg{
+			path:     path,
+			children: children,
+			err:      err,
+			id:       id,
+		}
+	}
+}
+
+// loadSelected returns the command loading the children of the selected node
+// if it is an opened lazy node.
+func (s *State) loadSelected() bento.Cmd {
+	index := s.selectedIndex()
+	if index < 0 || !s.visible[index].lazy || !s.IsOpened(s.selected...) {
+		return nil
+	}
+
+	return s.Load(s.selected...)
+}
+
+func (s *State) setLoaded(msg LoadedMsg) {
+	key := pathKey(msg.path)
+
+	if msg.err != nil {
+		if s.loadErrs == nil {
+			s.loadErrs = make(map[string]error)
+		}
+
+		s.loadErrs[key] = msg.err
+
+		return
+	}
+
+	if s.loaded == nil {
+		s.loaded = make(map[string][]Node)
+	}
+
+	s.loaded[key] = msg.children
+	delete(s.loadErrs, key)
+}
+
+// LoadError returns the error of the last load of the children of the node with the given path, if any.
+func (s *State) LoadError(path ...string) error {
+	return s.loadErrs[pathKey(path)]
+}
+
+// Reload forgets the lazily loaded children of the node with the given path
+// and returns the command loading them again if the node is opened, see [State.Load].
+func (s *State) Reload(path ...string) bento.Cmd {
+	delete(s.loaded, pathKey(path))
+	delete(s.loadErrs, pathKey(path))
+
+	if !s.IsOpened(path...) {
+		return nil
+	}
+
+	return s.Load(path...)
+}
+
+func pathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
@@ -0,0 +1,255 @@
+package treewidget
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/blockwidget"
+	"github.com/metafates/bento/symbol"
+	"github.com/rivo/uniseg"
+)
+
+// Loader loads the children of the lazy node with the given path,
+// see [Node.WithLazyChildren] and [State.SetLoader].
+//
+// It is called outside of the render, so it may block, e.g. to read a directory.
+// Loaded children are kept in the state until [State.Reload].
+type Loader func(path []string) ([]Node, error)
+
+var (
+	_ bento.StatefulWidget[*State] = (*Tree)(nil)
+	_ bento.Widget                 = (*Tree)(nil)
+)
+
+// Tree is a widget to display hierarchical data, e.g. a file explorer.
+type Tree struct {
+	roots []Node
+
+	block           *blockwidget.Block
+	style           bento.Style
+	guideStyle      bento.Style
+	highlightStyle  bento.Style
+	highlightSymbol string
+
+	openedSymbol string
+	closedSymbol string
+}
+
+func New(roots ...Node) Tree {
+	return Tree{
+		roots:           roots,
+		block:           nil,
+		style:           bento.NewStyle(),
+		guideStyle:      bento.NewStyle(),
+		highlightStyle:  bento.NewStyle(),
+		highlightSymbol: "",
+		openedSymbol:    symbol.ArrowDown,
+		closedSymbol:    symbol.ArrowRight,
+	}
+}
+
+func (t Tree) WithRoots(roots ...Node) Tree {
+	t.roots = roots
+	return t
+}
+
+func (t Tree) WithBlock(block blockwidget.Block) Tree {
+	t.block = &block
+	return t
+}
+
+func (t Tree) WithStyle(style bento.Style) Tree {
+	t.style = style
+	return t
+}
+
+// WithGuideStyle sets the style of the guide lines and the expand symbols.
+func (t Tree) WithGuideStyle(style bento.Style) Tree {
+	t.guideStyle = style
+	return t
+}
+
+func (t Tree) WithHighlightStyle(style bento.Style) Tree {
+	t.highlightStyle = style
+	return t
+}
+
+func (t Tree) WithHighlightSymbol(symbol string) Tree {
+	t.highlightSymbol = symbol
+	return t
+}
+
+// WithExpandSymbols sets the symbols displayed before opened and closed nodes.
+func (t Tree) WithExpandSymbols(opened, closed string) Tree {
+	t.openedSymbol = opened
+	t.closedSymbol = closed
+	return t
+}
+
+// Render implements bento.Widget.
+func (t Tree) Render(area bento.Rect, buffer *bento.Buffer) {
+	state := NewState()
+
+	t.RenderStateful(area, buffer, &state)
+}
+
+func (t Tree) RenderStateful(area bento.Rect, buffer *bento.Buffer, state *State) {
+	buffer.SetStyle(area, t.style)
+
+	treeArea := area
+	if t.block != nil {
+		t.block.Render(area, buffer)
+		treeArea = t.block.Inner(area)
+	}
+
+	rows := t.flatten(state)
+
+	state.visible = make([]visibleNode, len(rows))
+	for i, r := range rows {
+		state.visible[i] = visibleNode{path: r.path, expandable: r.node.Expandable(), lazy: r.node.lazy}
+	}
+
+	selected := state.selectedIndex()
+
+	// The selected node is hidden, e.g. its parent was closed.
+	// Select the closest visible ancestor.
+	for selected < 0 && len(state.selected) > 1 {
+		state.selected = state.selected[:len(state.selected)-1]
+		selected = state.selectedIndex()
+	}
+
+	if selected < 0 {
+		state.selected = nil
+	}
+
+	if treeArea.IsEmpty() || len(rows) == 0 {
+		return
+	}
+
+	state.offset = min(state.offset, max(0, len(rows)-treeArea.Height))
+
+	if selected >= 0 {
+		if selected < state.offset {
+			state.offset = selected
+		} else if selected >= state.offset+treeArea.Height {
+			state.offset = selected - treeArea.Height + 1
+		}
+	}
+
+	symbolWidth := uniseg.StringWidth(t.highlightSymbol)
+	blankSymbol := strings.Repeat(" ", symbolWidth)
+
+	for i := state.offset; i < min(len(rows), state.offset+treeArea.Height); i++ {
+		r := rows[i]
+
+		rowArea := bento.Rect{
+			X:      treeArea.X,
+			Y:      treeArea.Y + i - state.offset,
+			Width:  treeArea.Width,
+			Height: 1,
+		}
+
+		highlightSymbol := blankSymbol
+		if i == selected {
+			highlightSymbol = t.highlightSymbol
+		}
+
+		x, _ := buffer.SetStringN(rowArea.X, rowArea.Y, highlightSymbol, rowArea.Width, t.style)
+		x, _ = buffer.SetStringN(x, rowArea.Y, t.prefix(r, state), rowArea.Right()-x, t.guideStyle)
+
+		r.node.label.Render(bento.Rect{
+			X:      x,
+			Y:      rowArea.Y,
+			Width:  max(0, rowArea.Right()-x),
+			Height: 1,
+		}, buffer)
+
+		if i == selected {
+			buffer.SetStyle(rowArea, t.highlightStyle)
+		}
+	}
+}
+
+// prefix returns the guide lines and the expand symbol of the row.
+func (t Tree) prefix(r row, state *State) string {
+	var b strings.Builder
+
+	// the roots have no guide lines
+	for _, last := range r.lastAncestors[min(1, len(r.lastAncestors)):] {
+		if last {
+			b.WriteString("   ")
+		} else {
+			b.WriteString(symbol.LineVertical + "  ")
+		}
+	}
+
+	if len(r.path) > 1 {
+		if r.last {
+			b.WriteString(symbol.LineBottomLeft + symbol.LineHorizontal + " ")
+		} else {
+			b.WriteString(symbol.LineVerticalRight + symbol.LineHorizontal + " ")
+		}
+	}
+
+	expandSymbol := strings.Repeat(" ", max(uniseg.StringWidth(t.openedSymbol), uniseg.StringWidth(t.closedSymbol)))
+
+	if r.node.Expandable() {
+		if state.IsOpened(r.path...) {
+			expandSymbol = t.openedSymbol
+		} else {
+			expandSymbol = t.closedSymbol
+		}
+	}
+
+	b.WriteString(expandSymbol + " ")
+
+	return b.String()
+}
+
+type row struct {
+	node Node
+	path []string
+	last bool
+
+	// lastAncestors reports for each ancestor whether it is the last of its siblings.
+	lastAncestors []bool
+}
+
+// flatten returns the visible nodes in order.
+func (t Tree) flatten(state *State) []row {
+	var rows []row
+
+	var walk func(nodes []Node, parent []string, lastAncestors []bool)
+
+	walk = func(nodes []Node, parent []string, lastAncestors []bool) {
+		for i, n := range nodes {
+			r := row{
+				node:          n,
+				path:          append(slices.Clone(parent), n.id),
+				last:          i == len(nodes)-1,
+				lastAncestors: lastAncestors,
+			}
+
+			rows = append(rows, r)
+
+			if n.Expandable() && state.IsOpened(r.path...) {
+				walk(t.children(n, r.path, state), r.path, append(slices.Clone(lastAncestors), r.last))
+			}
+		}
+	}
+
+	walk(t.roots, nil, nil)
+
+	return rows
+}
+
+// children returns the children of the node.
+// The children of a lazy node are empty until they are loaded into the state.
+func (t Tree) children(node Node, path []string, state *State) []Node {
+	if !node.lazy {
+		return node.children
+	}
+
+	return state.loaded[pathKey(path)]
+}
@@ -0,0 +1,229 @@
+package treewidget
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/metafates/bento"
+	"github.com/metafates/bento/bentotest"
+	"github.com/stretchr/testify/require"
+)
+
+func statefulWidget(widget Tree, state *State, width, height int) bento.Buffer {
+	buffer := bento.NewBufferEmpty(bento.Rect{Width: width, Height: height})
+
+	widget.RenderStateful(buffer.Area(), &buffer, state)
+
+	return buffer
+}
+
+func press(t *testing.T, state *State, keys ...string) {
+	t.Helper()
+
+	for _, key := range keys {
+		var msg bento.KeyMsg
+
+		switch key {
+		case "down":
+			msg = bento.KeyMsg{Type: bento.KeyDown}
+		case "up":
+			msg = bento.KeyMsg{Type: bento.KeyUp}
+		case "left":
+			msg = bento.KeyMsg{Type: bento.KeyLeft}
+		case "right":
+			msg = bento.KeyMsg{Type: bento.KeyRight}
+		case "home":
+			msg = bento.KeyMsg{Type: bento.KeyHome}
+		case "end":
+			msg = bento.KeyMsg{Type: bento.KeyEnd}
+		default:
+			msg = bento.KeyMsg{Type: bento.KeyRunes, Runes: []rune(key)}
+		}
+
+		handled, _ := state.TryUpdate(msg)
+		require.True(t, handled, "key %q", key)
+	}
+}
+
+func fileTree() Tree {
+	return New(
+		NewNodeStr("src",
+			NewNodeStr("app",
+				NewNodeStr("app.go"),
+				NewNodeStr("run.go"),
+			),
+			NewNodeStr("main.go"),
+		),
+		NewNodeStr("go.mod"),
+	).WithHighlightSymbol(">")
+}
+
+func TestTree_Navigation(t *testing.T) {
+	tree := fileTree()
+	state := NewState()
+
+	buffer := statefulWidget(tree, &state, 16, 4)
+	bentotest.AssertBufferLines(t, []string{
+		" ▶ src          ",
+		"   go.mod       ",
+		"                ",
+		"                ",
+	}, buffer)
+
+	press(t, &state, "down", "right")
+
+	buffer = statefulWidget(tree, &state, 16, 4)
+	bentotest.AssertBufferLines(t, []string{
+		">▼ src          ",
+		" ├─ ▶ app       ",
+		" └─   main.go   ",
+		"   go.mod       ",
+	}, buffer)
+
+	press(t, &state, "down", "right")
+
+	buffer = statefulWidget(tree, &state, 16, 6)
+	bentotest.AssertBufferLines(t, []string{
+		" ▼ src          ",
+		">├─ ▼ app       ",
+		" │  ├─   app.go ",
+		" │  └─   run.go ",
+		" └─   main.go   ",
+		"   go.mod       ",
+	}, buffer)
+
+	// leaves can not be opened, left goes to the parent
+	press(t, &state, "end", "up", "right", "left")
+
+	path, ok := state.Selected()
+	require.True(t, ok)
+	require.Equal(t, []string{"src"}, path)
+
+	// left closes the opened node
+	press(t, &state, "left")
+	require.False(t, state.IsOpened("src"))
+
+	press(t, &state, "home")
+
+	path, ok = state.Selected()
+	require.True(t, ok)
+	require.Equal(t, []string{"src"}, path)
+}
+
+func TestTree_HiddenSelectionMovesToAncestor(t *testing.T) {
+	tree := fileTree()
+	state := NewState()
+	state.Open("src")
+	state.Open("src", "app")
+	state.Select("src", "app", "run.go")
+
+	statefulWidget(tree, &state, 16, 6)
+
+	state.Close("src")
+
+	buffer := statefulWidget(tree, &state, 16, 2)
+	bentotest.AssertBufferLines(t, []string{
+		">▶ src          ",
+		"   go.mod       ",
+	}, buffer)
+
+	path, ok := state.Selected()
+	require.True(t, ok)
+	require.Equal(t, []string{"src"}, path)
+}
+
+func TestTree_LazyChildren(t *testing.T) {
+	var loads int
+
+	tree := New(NewNodeStr("root").WithLazyChildren())
+
+	state := NewState()
+	state.SetLoader(func(path []string) ([]Node, error) {
+		loads++
+
+		if loads == 2 {
+			return nil, errors.New("failed")
+		}
+
+		return []Node{NewNodeStr(path[0] + "-child")}, nil
+	})
+
+	statefulWidget(tree, &state, 16, 2)
+	require.Zero(t, loads)
+
+	press(t, &state, "down")
+
+	// opening the node returns the command loading its children
+	handled, cmd := state.TryUpdate(bento.KeyMsg{Type: bento.KeyRight})
+	require.True(t, handled)
+	require.NotNil(t, cmd)
+	require.Zero(t, loads)
+
+	// the render does not load the children
+	buffer := statefulWidget(tree, &state, 16, 2)
+	bentotest.AssertBufferLines(t, []string{
+		"▼ root          ",
+		"                ",
+	}, buffer)
+
+	msg := cmd()
+	require.Equal(t, 1, loads)
+
+	// the message of another state is ignored
+	other := NewState()
+	handled, _ = other.TryUpdate(msg)
+	require.False(t, handled)
+
+	handled, _ = state.TryUpdate(msg)
+	require.True(t, handled)
+
+	buffer = statefulWidget(tree, &state, 16, 2)
+	bentotest.AssertBufferLines(t, []string{
+		"▼ root          ",
+		"└─   root-child ",
+	}, buffer)
+
+	// the loaded children are kept
+	_, cmd = state.TryUpdate(bento.KeyMsg{Type: bento.KeyRight})
+	require.Nil(t, cmd)
+
+	// the error of the loader is reported
+	cmd = state.Reload("root")
+	require.NotNil(t, cmd)
+
+	msg = cmd()
+	require.EqualError(t, msg.(LoadedMsg).Err(), "failed")
+
+	state.TryUpdate(msg)
+	require.EqualError(t, state.LoadError("root"), "failed")
+
+	buffer = statefulWidget(tree, &state, 16, 2)
+	bentotest.AssertBufferLines(t, []string{
+		"▼ root          ",
+		"                ",
+	}, buffer)
+
+	// and the children are loaded again the next time
+	state.TryUpdate(state.Load("root")())
+	require.NoError(t, state.LoadError("root"))
+	require.Equal(t, 3, loads)
+}
+
+func TestTree_ScrollsToSelected(t *testing.T) {
+	tree := fileTree()
+	state := NewState()
+	state.Open("src")
+	state.Open("src", "app")
+
+	statefulWidget(tree, &state, 16, 3)
+
+	press(t, &state, "G")
+
+	buffer := statefulWidget(tree, &state, 16, 3)
+	bentotest.AssertBufferLines(t, []string{
+		" │  └─   run.go ",
+		" └─   main.go   ",
+		">  go.mod       ",
+	}, buffer)
+	require.Equal(t, 3, state.Offset())
+}
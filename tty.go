@@ -1,9 +1,11 @@
 package bento
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"os/exec"
 	"time"
 
 	"github.com/muesli/cancelreader"
@@ -28,14 +30,31 @@ func (a *appRunner) restoreTerminal() error {
 		return fmt.Errorf("disable bracketed paste: %w", err)
 	}
 
+	if err := a.terminal.SetCursorShape(CursorShapeDefault); err != nil {
+		return fmt.Errorf("set cursor shape: %w", err)
+	}
+
 	if err := a.terminal.ShowCursor(); err != nil {
 		return fmt.Errorf("show cursor: %w", err)
 	}
-	// a.disableMouse()
 
-	// if p.renderer.reportFocus() {
-	// p.renderer.disableReportFocus()
-	// }
+	if a.terminal.MouseEnabled() {
+		if err := a.terminal.DisableMouse(); err != nil {
+			return fmt.Errorf("disable mouse: %w", err)
+		}
+	}
+
+	if a.terminal.ReportFocusEnabled() {
+		if err := a.terminal.DisableReportFocus(); err != nil {
+			return fmt.Errorf("disable report focus: %w", err)
+		}
+	}
+
+	if a.terminal.KeyboardEnhancementEnabled() {
+		if err := a.terminal.PopKeyboardEnhancement(); err != nil {
+			return fmt.Errorf("pop keyboard enhancement: %w", err)
+		}
+	}
 
 	// if a.renderer.altScreen() {
 	// p.renderer.exitAltScreen()
@@ -50,8 +69,129 @@ func (a *appRunner) restoreTerminal() error {
 	return nil
 }
 
+// canSuspend reports whether the process can be suspended.
+// Only the local terminal is given back to the shell, so e.g. SSH sessions can't suspend the server.
+func (a *appRunner) canSuspend() bool {
+	return suspendSupported && a.outputIsTerminal()
+}
+
+// suspend gives the terminal back to the shell and stops the process until it is continued.
+// Then the terminal is set up again and [ResumeMsg] is sent.
+func (a *appRunner) suspend() error {
+	if !a.canSuspend() {
+		return nil
+	}
+
+	if err := a.releaseTerminal(); err != nil {
+		return fmt.Errorf("release terminal: %w", err)
+	}
+
+	suspendProcess()
+
+	if err := a.reacquireTerminal(); err != nil {
+		return fmt.Errorf("reacquire terminal: %w", err)
+	}
+
+	go a.Send(ResumeMsg{})
+
+	return nil
+}
+
+// exec runs the process with the terminal released to it
+// and sends [ExecFinishedMsg] once it exits.
+func (a *appRunner) exec(process *exec.Cmd) error {
+	if err := a.releaseTerminal(); err != nil {
+		return fmt.Errorf("release terminal: %w", err)
+	}
+
+	if process.Stdin == nil {
+		process.Stdin = a.terminal.Input()
+	}
+
+	if process.Stdout == nil {
+		process.Stdout = a.terminal.Output()
+	}
+
+	if process.Stderr == nil {
+		process.Stderr = a.terminal.Output()
+	}
+
+	runErr := process.Run()
+
+	if err := a.reacquireTerminal(); err != nil {
+		return fmt.Errorf("reacquire terminal: %w", err)
+	}
+
+	go a.Send(ExecFinishedMsg{Process: process, Err: runErr})
+
+	return nil
+}
+
+// releaseTerminal stops reading the input and restores the terminal,
+// so that it can be used by another process.
+//
+// The modes enabled at runtime are remembered to be enabled again by [appRunner.reacquireTerminal].
+func (a *appRunner) releaseTerminal() error {
+	if a.cancelReader != nil {
+		if a.cancelReader.Cancel() {
+			a.waitForReadLoop()
+		}
+
+		_ = a.cancelReader.Close()
+	}
+
+	a.mouseMode = a.terminal.mouseMode
+	a.reportFocus = a.terminal.ReportFocusEnabled()
+
+	if isInline(a.terminal.Viewport()) {
+		// The frame is cleared instead of kept above the output of the other process,
+		// which would leave a stale frame behind. The viewport is placed below that output
+		// by [appRunner.reacquireTerminal].
+		if err := a.terminal.Clear(); err != nil {
+			return fmt.Errorf("clear: %w", err)
+		}
+
+		return a.restoreTerminal()
+	}
+
+	return a.restore()
+}
+
+// reacquireTerminal sets up the terminal released by [appRunner.releaseTerminal] again
+// and restarts reading the input. The next frame is drawn from scratch.
+func (a *appRunner) reacquireTerminal() error {
+	if err := a.init(); err != nil {
+		return fmt.Errorf("init: %w", err)
+	}
+
+	// before the input is read again, since the cursor position is queried
+	if err := a.terminal.ResetViewport(); err != nil {
+		return fmt.Errorf("reset viewport: %w", err)
+	}
+
+	if err := a.initCancelReader(); err != nil {
+		return fmt.Errorf("init cancel reader: %w", err)
+	}
+
+	if err := a.terminal.Clear(); err != nil {
+		return fmt.Errorf("clear: %w", err)
+	}
+
+	// the terminal may have been resized meanwhile
+	go a.checkResize()
+
+	return nil
+}
+
 func (a *appRunner) initCancelReader() error {
-	r, err := newInputReader(a.terminal)
+	if a.terminal.Input() == nil {
+		// input is disabled
+		return nil
+	}
+
+	// The input is read directly instead of through the terminal, so that the reading
+	// can be canceled when the input is a file, e.g. to hand it to another process.
+	r, err := newInputReader(a.terminal.Input())
 	if err != nil {
 		return fmt.Errorf("new reader: %w", err)
 	}
@@ -59,15 +199,21 @@ func (a *appRunner) initCancelReader() error {
 	a.cancelReader = r
 	a.readLoopDone = make(chan struct{})
 
-	go a.readLoop()
+	go a.readLoop(a.terminal.takePendingInput())
 
 	return nil
 }
 
-func (a *appRunner) readLoop() {
+// readLoop reads the input until it's canceled.
+// The pending input, e.g. read along with the cursor position report, is read first.
+func (a *appRunner) readLoop(pending []byte) {
 	defer close(a.readLoopDone)
 
-	err := readInputs(a.ctx, a.msgs, a.cancelReader)
+	err := readInputs(a.ctx, a.msgs, bytes.NewReader(pending), a.maxPasteSize)
+	if errors.Is(err, io.EOF) {
+		err = readInputs(a.ctx, a.msgs, a.cancelReader, a.maxPasteSize)
+	}
+
 	if !errors.Is(err, io.EOF) && !errors.Is(err, cancelreader.ErrCanceled) {
 		select {
 		case <-a.ctx.Done():
@@ -13,3 +13,8 @@ type (
 func (ViewportFullscreen) isViewport() {}
 func (ViewportInline) isViewport()     {}
 func (ViewportFixed) isViewport()      {}
+
+func isInline(viewport Viewport) bool {
+	_, ok := viewport.(ViewportInline)
+	return ok
+}